@@ -0,0 +1,140 @@
+package goption
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// AppendBinary appends the binary form of o to b: a presence byte, followed
+// by the value in little endian if it's present. Only Options of booleans
+// and numbers are supported, with int and uint always taking 8 bytes, so the
+// size of a present value is fixed by its type. Decode it with
+// UnmarshalBinary.
+func (o Option[T]) AppendBinary(b []byte) ([]byte, error) {
+	rv := reflect.ValueOf(&o.t).Elem()
+	if _, err := binarySize(rv.Type()); err != nil {
+		return nil, err
+	}
+	if !o.ok {
+		return append(b, 0), nil
+	}
+
+	b = append(b, 1)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int8, reflect.Uint8:
+		return append(b, byte(binaryBits(rv))), nil
+	case reflect.Int16, reflect.Uint16:
+		return binary.LittleEndian.AppendUint16(b, uint16(binaryBits(rv))), nil
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return binary.LittleEndian.AppendUint32(b, uint32(binaryBits(rv))), nil
+	default:
+		return binary.LittleEndian.AppendUint64(b, binaryBits(rv)), nil
+	}
+}
+
+// UnmarshalBinary decodes data, in the form of AppendBinary, as an Option[T].
+// It isn't a method so Options don't implement encoding.BinaryUnmarshaler,
+// which encodings like gob and CBOR would use instead of their own forms.
+func UnmarshalBinary[T any](data []byte) (Option[T], error) {
+	var o Option[T]
+	err := o.unmarshalBinary(data)
+	return o, err
+}
+
+func (o *Option[T]) unmarshalBinary(data []byte) error {
+	rv := reflect.ValueOf(&o.t).Elem()
+	size, err := binarySize(rv.Type())
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("binary %s is empty", reflect.TypeOf(o).Elem())
+	}
+	if data[0] == 0 {
+		if len(data) != 1 {
+			return fmt.Errorf("binary %s has %d trailing bytes", reflect.TypeOf(o).Elem(), len(data)-1)
+		}
+		o.ok, o.t = false, *new(T)
+		return nil
+	}
+	if len(data) != 1+size {
+		return fmt.Errorf("binary %s must be %d bytes, got %d", reflect.TypeOf(o).Elem(), 1+size, len(data))
+	}
+
+	var bits uint64
+	switch size {
+	case 1:
+		bits = uint64(data[1])
+	case 2:
+		bits = uint64(binary.LittleEndian.Uint16(data[1:]))
+	case 4:
+		bits = uint64(binary.LittleEndian.Uint32(data[1:]))
+	default:
+		bits = binary.LittleEndian.Uint64(data[1:])
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(bits != 0)
+	case reflect.Int8:
+		rv.SetInt(int64(int8(bits)))
+	case reflect.Int16:
+		rv.SetInt(int64(int16(bits)))
+	case reflect.Int32:
+		rv.SetInt(int64(int32(bits)))
+	case reflect.Int, reflect.Int64:
+		if rv.OverflowInt(int64(bits)) {
+			return fmt.Errorf("binary value %d overflows %s", int64(bits), rv.Type())
+		}
+		rv.SetInt(int64(bits))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.OverflowUint(bits) {
+			return fmt.Errorf("binary value %d overflows %s", bits, rv.Type())
+		}
+		rv.SetUint(bits)
+	case reflect.Float32:
+		rv.SetFloat(float64(math.Float32frombits(uint32(bits))))
+	case reflect.Float64:
+		rv.SetFloat(math.Float64frombits(bits))
+	}
+	o.ok = true
+
+	return nil
+}
+
+// binarySize returns the size of the binary form of values of type t.
+func binarySize(t reflect.Type) (int, error) {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return 1, nil
+	case reflect.Int16, reflect.Uint16:
+		return 2, nil
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 4, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Float64:
+		return 8, nil
+	}
+
+	return 0, fmt.Errorf("unsupported binary type %s, must be a boolean or number", t)
+}
+
+// binaryBits returns the bits of rv, a number.
+func binaryBits(rv reflect.Value) uint64 {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int())
+	case reflect.Float32:
+		return uint64(math.Float32bits(float32(rv.Float())))
+	case reflect.Float64:
+		return math.Float64bits(rv.Float())
+	}
+
+	return rv.Uint()
+}
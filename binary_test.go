@@ -0,0 +1,75 @@
+package goption
+
+import (
+	"bytes"
+	"encoding"
+	"math"
+	"testing"
+)
+
+func checkBinary[T comparable](t *testing.T, o Option[T], size int) {
+	t.Helper()
+	b, err := o.AppendBinary(nil)
+	if err != nil {
+		t.Fatalf("Failed marshalling %v: %s", o, err)
+	}
+	if len(b) != size {
+		t.Errorf("Expected %d bytes for %v, got %d", size, o, len(b))
+	}
+
+	decoded, err := UnmarshalBinary[T](b)
+	if err != nil {
+		t.Fatalf("Failed unmarshalling %v: %s", o, err)
+	}
+	if decoded != o {
+		t.Errorf("Expected %v, got %v", o, decoded)
+	}
+}
+
+type binaryLevel uint8
+
+func TestBinary(t *testing.T) {
+	checkBinary(t, Some(true), 2)
+	checkBinary(t, Some(int8(-3)), 2)
+	checkBinary(t, Some(int16(math.MinInt16)), 3)
+	checkBinary(t, Some(int32(-1)), 5)
+	checkBinary(t, Some(int64(math.MinInt64)), 9)
+	checkBinary(t, Some(-1), 9)
+	checkBinary(t, Some(uint64(math.MaxUint64)), 9)
+	checkBinary(t, Some(float32(1.5)), 5)
+	checkBinary(t, Some(math.Inf(-1)), 9)
+	checkBinary(t, Some(binaryLevel(7)), 2)
+	checkBinary(t, None[int64](), 1)
+
+	b, _ := Some(uint16(0x0102)).AppendBinary([]byte("x"))
+	if !bytes.Equal(b, []byte{'x', 1, 2, 1}) {
+		t.Errorf("Expected appended little endian value, got %v", b)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	if _, err := Some("text").AppendBinary(nil); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+
+	for _, data := range [][]byte{nil, {1, 0}, {0, 0}, {1, 0, 0, 0, 0, 0}} {
+		if _, err := UnmarshalBinary[int32](data); err == nil {
+			t.Errorf("Expected error for %v", data)
+		}
+	}
+	if u, err := UnmarshalBinary[uint8]([]byte{1, 0xff}); err != nil || u.Unwrap() != 0xff {
+		t.Errorf("Expected 255, got %v (%v)", u, err)
+	}
+}
+
+func TestBinaryNotMarshaler(t *testing.T) {
+	// Encodings preferring encoding.BinaryMarshaler must keep using their own
+	// forms, which support every T.
+	var o any = Some("text")
+	if _, ok := o.(encoding.BinaryMarshaler); ok {
+		t.Errorf("Expected Option not to implement encoding.BinaryMarshaler")
+	}
+	if _, ok := any(&Option[int]{}).(encoding.BinaryUnmarshaler); ok {
+		t.Errorf("Expected *Option not to implement encoding.BinaryUnmarshaler")
+	}
+}
@@ -0,0 +1,30 @@
+package goption
+
+import (
+	"cmp"
+)
+
+// Clamp limits v to the optional bounds lo and hi. Empty bounds don't limit
+// v. If both are present and lo > hi, hi wins.
+func Clamp[T cmp.Ordered](v T, lo, hi Option[T]) T {
+	if lo.ok && v < lo.t {
+		v = lo.t
+	}
+	if hi.ok && v > hi.t {
+		v = hi.t
+	}
+
+	return v
+}
+
+// Between reports whether lo <= v <= hi, treating empty bounds as unbounded.
+func Between[T cmp.Ordered](v T, lo, hi Option[T]) bool {
+	if lo.ok && v < lo.t {
+		return false
+	}
+	if hi.ok && v > hi.t {
+		return false
+	}
+
+	return true
+}
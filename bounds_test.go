@@ -0,0 +1,38 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	cases := []struct {
+		v, expected int
+		lo, hi      Option[int]
+	}{
+		{5, 5, None[int](), None[int]()},
+		{-1, 0, Some(0), None[int]()},
+		{11, 10, None[int](), Some(10)},
+		{5, 5, Some(0), Some(10)},
+		{5, 3, Some(7), Some(3)},
+	}
+	for _, c := range cases {
+		if got := Clamp(c.v, c.lo, c.hi); got != c.expected {
+			t.Errorf("Clamp(%d, %v, %v): expected %d, got %d", c.v, c.lo, c.hi, c.expected, got)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	if !Between(5, None[int](), None[int]()) {
+		t.Errorf("Expected unbounded value to be in range")
+	}
+	if !Between(0, Some(0), Some(0)) {
+		t.Errorf("Expected bounds to be inclusive")
+	}
+	if Between(-1, Some(0), None[int]()) {
+		t.Errorf("Expected value below lower bound to be out of range")
+	}
+	if Between("z", None[string](), Some("m")) {
+		t.Errorf("Expected value above upper bound to be out of range")
+	}
+}
@@ -0,0 +1,202 @@
+package goption
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// OptionMap is a cache of Options used by GetOrFill. Caching an empty option
+// records that the key is known to be absent, which Lookup tells apart from
+// a key which wasn't cached by its second result.
+type OptionMap[K comparable, V any] interface {
+	// Lookup returns the option cached for key and whether there is one.
+	Lookup(key K) (o Option[V], cached bool)
+	// Store caches o for key for ttl.
+	Store(key K, o Option[V], ttl time.Duration)
+}
+
+// MemoryOptionMap is an in-memory OptionMap. It's safe for concurrent use.
+type MemoryOptionMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]memoryEntry[V]
+	now     func() time.Time
+}
+
+type memoryEntry[V any] struct {
+	o       Option[V]
+	expires time.Time
+}
+
+// NewMemoryOptionMap returns an empty MemoryOptionMap.
+func NewMemoryOptionMap[K comparable, V any]() *MemoryOptionMap[K, V] {
+	return &MemoryOptionMap[K, V]{entries: make(map[K]memoryEntry[V]), now: time.Now}
+}
+
+// Lookup implements OptionMap. Expired entries are removed.
+func (m *MemoryOptionMap[K, V]) Lookup(key K) (Option[V], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return None[V](), false
+	}
+	if !m.now().Before(e.expires) {
+		delete(m.entries, key)
+		return None[V](), false
+	}
+	return e.o, true
+}
+
+// Store implements OptionMap
+func (m *MemoryOptionMap[K, V]) Store(key K, o Option[V], ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = memoryEntry[V]{o: o, expires: m.now().Add(ttl)}
+}
+
+// Delete removes the entry of key.
+func (m *MemoryOptionMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+}
+
+// pendingFill is a fill of GetOrFill which callers wait for.
+type pendingFill[V any] struct {
+	done     chan struct{}
+	o        Option[V]
+	err      error
+	panicked *fillPanic
+
+	// mu guards waiters, the number of calls waiting for the fill, and
+	// abandoned, set once they all returned and the fill was canceled.
+	mu        sync.Mutex
+	waiters   int
+	abandoned bool
+	cancel    context.CancelFunc
+}
+
+// join adds a waiting call, unless the fill was abandoned.
+func (f *pendingFill[V]) join() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.abandoned {
+		return false
+	}
+	f.waiters++
+	return true
+}
+
+// leave removes a call which stopped waiting because its context is done.
+// Once no call is left the fill is canceled and no longer shared.
+func (f *pendingFill[V]) leave(fk fillKey) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.waiters--
+	if f.waiters == 0 {
+		f.abandoned = true
+		pendingFills.CompareAndDelete(fk, f)
+		f.cancel()
+	}
+}
+
+// fillPanic is what callers of GetOrFill panic with if fill panicked.
+type fillPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *fillPanic) Error() string {
+	return fmt.Sprintf("goption: fill panicked: %v\n\n%s", p.value, p.stack)
+}
+
+// Unwrap returns the value fill panicked with if it's an error.
+func (p *fillPanic) Unwrap() error {
+	err, _ := p.value.(error)
+	return err
+}
+
+type fillKey struct {
+	cache, key any
+}
+
+var pendingFills sync.Map // map[fillKey]*pendingFill[V]
+
+// GetOrFill returns the option cached for key, which may be empty if the key
+// is known to be absent. If nothing is cached it calls fill and caches its
+// result, empty or not, for ttl. Errors aren't cached.
+//
+// Concurrent calls for the same cache and key share one call of fill. It's
+// passed a context with the values of the call which started it, which isn't
+// canceled with that call's context but once every call waiting for fill has
+// returned. Every call returns the error of its own context once it's done,
+// without waiting for fill. cache must be comparable, like a pointer.
+//
+// If fill panics, the calls waiting for it panic with an error holding the
+// value and the stack of fill, which unwraps to the value if it's an error.
+func GetOrFill[K comparable, V any](ctx context.Context, cache OptionMap[K, V], key K, fill func(ctx context.Context) (Option[V], error), ttl time.Duration) (Option[V], error) {
+	if o, cached := cache.Lookup(key); cached {
+		return o, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return None[V](), err
+	}
+
+	fk := fillKey{cache: cache, key: key}
+	var f *pendingFill[V]
+	for f == nil {
+		fillCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		started := &pendingFill[V]{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		pending, loaded := pendingFills.LoadOrStore(fk, started)
+		if !loaded {
+			f = started
+			go f.run(fillCtx, fk, func(ctx context.Context) (Option[V], error) {
+				o, err := fill(ctx)
+				if err == nil {
+					cache.Store(key, o, ttl)
+				}
+				return o, err
+			})
+			break
+		}
+
+		cancel()
+		// An abandoned fill is no longer shared, so try again.
+		if joined := pending.(*pendingFill[V]); joined.join() {
+			f = joined
+		}
+	}
+
+	select {
+	case <-f.done:
+		if f.panicked != nil {
+			panic(f.panicked)
+		}
+		return f.o, f.err
+	case <-ctx.Done():
+		f.leave(fk)
+		return None[V](), ctx.Err()
+	}
+}
+
+// run calls fill with ctx and stores its result in f.
+func (f *pendingFill[V]) run(ctx context.Context, fk fillKey, fill func(ctx context.Context) (Option[V], error)) {
+	defer close(f.done)
+	defer pendingFills.CompareAndDelete(fk, f)
+	defer f.cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			f.panicked = &fillPanic{value: r, stack: debug.Stack()}
+		}
+	}()
+
+	f.o, f.err = fill(ctx)
+}
@@ -0,0 +1,204 @@
+package goption
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryOptionMap(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryOptionMap[string, int]()
+	m.now = func() time.Time { return now }
+
+	if _, cached := m.Lookup("a"); cached {
+		t.Errorf("Expected nothing cached")
+	}
+	m.Store("a", None[int](), time.Minute)
+	if o, cached := m.Lookup("a"); !cached || o.Ok() {
+		t.Errorf("Expected cached None, got %v (%v)", o, cached)
+	}
+
+	now = now.Add(time.Minute)
+	if _, cached := m.Lookup("a"); cached {
+		t.Errorf("Expected entry to expire")
+	}
+
+	m.Store("b", Some(1), time.Minute)
+	m.Delete("b")
+	if _, cached := m.Lookup("b"); cached {
+		t.Errorf("Expected entry to be deleted")
+	}
+}
+
+func TestGetOrFill(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryOptionMap[int, string]()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fill := func(context.Context) (Option[string], error) {
+		calls.Add(1)
+		<-release
+		return None[string](), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if o, err := GetOrFill[int, string](ctx, cache, 1, fill, time.Minute); err != nil || o.Ok() {
+				t.Errorf("Expected None, got %v (%v)", o, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if _, err := GetOrFill[int, string](ctx, cache, 1, fill, time.Minute); err != nil || calls.Load() != 1 {
+		t.Errorf("Expected a single fill with the absence cached, got %d fills (%v)", calls.Load(), err)
+	}
+}
+
+func TestGetOrFillErrors(t *testing.T) {
+	cache := NewMemoryOptionMap[string, int]()
+	failure := errors.New("failure")
+	if _, err := GetOrFill[string, int](context.Background(), cache, "a", func(context.Context) (Option[int], error) {
+		return None[int](), failure
+	}, time.Minute); err != failure {
+		t.Errorf("Expected failure, got %v", err)
+	}
+	if _, cached := cache.Lookup("a"); cached {
+		t.Errorf("Expected error not to be cached")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+	_, err := GetOrFill[string, int](ctx, cache, "b", func(context.Context) (Option[int], error) {
+		<-release
+		return Some(1), nil
+	}, time.Minute)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline to be respected, got %v", err)
+	}
+
+	if _, err := GetOrFill[string, int](ctx, cache, "c", nil, time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected expired context to fail before filling, got %v", err)
+	}
+}
+
+func TestGetOrFillPanics(t *testing.T) {
+	cache := NewMemoryOptionMap[string, int]()
+	failure := errors.New("failure")
+	release := make(chan struct{})
+	fill := func(context.Context) (Option[int], error) {
+		<-release
+		panic(failure)
+	}
+
+	var wg sync.WaitGroup
+	var panics atomic.Int32
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if err, ok := recover().(error); ok && errors.Is(err, failure) {
+					panics.Add(1)
+				}
+			}()
+			GetOrFill[string, int](context.Background(), cache, "a", fill, time.Minute)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if panics.Load() != 3 {
+		t.Errorf("Expected every caller to panic with the failure, got %d", panics.Load())
+	}
+	if _, cached := cache.Lookup("a"); cached {
+		t.Errorf("Expected nothing cached after a panic")
+	}
+	if o, err := GetOrFill[string, int](context.Background(), cache, "a", func(context.Context) (Option[int], error) {
+		return Some(1), nil
+	}, time.Minute); err != nil || o.Unwrap() != 1 {
+		t.Errorf("Expected a new fill after a panic, got %v (%v)", o, err)
+	}
+}
+
+func TestGetOrFillLeaderCanceled(t *testing.T) {
+	cache := NewMemoryOptionMap[string, int]()
+	started, release := make(chan struct{}), make(chan struct{})
+	fill := func(ctx context.Context) (Option[int], error) {
+		close(started)
+		select {
+		case <-release:
+			return Some(1), nil
+		case <-ctx.Done():
+			return None[int](), ctx.Err()
+		}
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := GetOrFill[string, int](leaderCtx, cache, "a", fill, time.Minute)
+		leaderErr <- err
+	}()
+	<-started
+
+	follower := make(chan Option[int])
+	go func() {
+		o, err := GetOrFill[string, int](context.Background(), cache, "a", fill, time.Minute)
+		if err != nil {
+			t.Errorf("Expected the follower to get the value, got %v", err)
+		}
+		follower <- o
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the leader to return its own error, got %v", err)
+	}
+	close(release)
+	if o := <-follower; o.UnwrapOr(0) != 1 {
+		t.Errorf("Expected the follower to get the value, got %v", o)
+	}
+}
+
+func TestGetOrFillAbandoned(t *testing.T) {
+	cache := NewMemoryOptionMap[string, int]()
+	canceled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := GetOrFill[string, int](ctx, cache, "a", func(ctx context.Context) (Option[int], error) {
+		<-ctx.Done()
+		close(canceled)
+		return None[int](), ctx.Err()
+	}, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the caller's error, got %v", err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected fill to be canceled once no call waits for it")
+	}
+	if o, err := GetOrFill[string, int](context.Background(), cache, "a", func(context.Context) (Option[int], error) {
+		return Some(2), nil
+	}, time.Minute); err != nil || o.Unwrap() != 2 {
+		t.Errorf("Expected a new fill after the abandoned one, got %v (%v)", o, err)
+	}
+}
@@ -0,0 +1,265 @@
+// Package civil implements dates and times of day without a time zone, for
+// DATE, TIME and TIMESTAMP WITHOUT TIME ZONE style columns. They scan from
+// and convert to SQL values and text, so they can be used in goption.Option
+// fields without going through time.Time with fake components.
+package civil
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a date without a time of day or time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date t falls on in its location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a date in the format "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String formats d as "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid reports whether d is a real date.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or
+// after other.
+func (d Date) Compare(other Date) int {
+	return d.In(time.UTC).Compare(other.In(time.UTC))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(data []byte) error {
+	var err error
+	*d, err = ParseDate(string(data))
+	return err
+}
+
+// Scan implements sql.Scanner
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type civil.Date", src)
+}
+
+// Value implements driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Time is a time of day without a date or time zone.
+type Time struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOf returns the time of day of t in its location.
+func TimeOf(t time.Time) Time {
+	var tm Time
+	tm.Hour, tm.Minute, tm.Second = t.Clock()
+	tm.Nanosecond = t.Nanosecond()
+	return tm
+}
+
+// ParseTime parses a time of day in the format "15:04:05", optionally with a
+// fraction of a second.
+func ParseTime(s string) (Time, error) {
+	t, err := time.Parse("15:04:05.999999999", s)
+	if err != nil {
+		return Time{}, err
+	}
+	return TimeOf(t), nil
+}
+
+// String formats t as "15:04:05", with as many fractional digits as needed.
+func (t Time) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+
+	return s + strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+}
+
+// IsValid reports whether t is a real time of day.
+func (t Time) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or
+// after other.
+func (t Time) Compare(other Time) int {
+	return time.Date(0, 1, 1, t.Hour, t.Minute, t.Second, t.Nanosecond, time.UTC).
+		Compare(time.Date(0, 1, 1, other.Hour, other.Minute, other.Second, other.Nanosecond, time.UTC))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *Time) UnmarshalText(data []byte) error {
+	var err error
+	*t, err = ParseTime(string(data))
+	return err
+}
+
+// Scan implements sql.Scanner
+func (t *Time) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*t = TimeOf(v)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type civil.Time", src)
+}
+
+// Value implements driver.Valuer
+func (t Time) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// DateTime is a date and time of day without a time zone.
+type DateTime struct {
+	Date Date
+	Time Time
+}
+
+// DateTimeOf returns the date and time of day of t in its location.
+func DateTimeOf(t time.Time) DateTime {
+	return DateTime{Date: DateOf(t), Time: TimeOf(t)}
+}
+
+// ParseDateTime parses a date and time of day in the format
+// "2006-01-02T15:04:05", optionally with a fraction of a second. The date
+// and time may be separated by a space instead of "T".
+func ParseDateTime(s string) (DateTime, error) {
+	date, clock, ok := strings.Cut(s, "T")
+	if !ok {
+		date, clock, ok = strings.Cut(s, " ")
+	}
+	if !ok {
+		return DateTime{}, fmt.Errorf("parsing civil.DateTime %q: missing time of day", s)
+	}
+
+	d, err := ParseDate(date)
+	if err != nil {
+		return DateTime{}, err
+	}
+	t, err := ParseTime(clock)
+	if err != nil {
+		return DateTime{}, err
+	}
+	return DateTime{Date: d, Time: t}, nil
+}
+
+// String formats dt as "2006-01-02T15:04:05", with as many fractional digits
+// as needed.
+func (dt DateTime) String() string {
+	return dt.Date.String() + "T" + dt.Time.String()
+}
+
+// IsValid reports whether dt is a real date and time of day.
+func (dt DateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Time.IsValid()
+}
+
+// In returns dt in loc.
+func (dt DateTime) In(loc *time.Location) time.Time {
+	return time.Date(dt.Date.Year, dt.Date.Month, dt.Date.Day,
+		dt.Time.Hour, dt.Time.Minute, dt.Time.Second, dt.Time.Nanosecond, loc)
+}
+
+// Compare returns -1, 0 or +1 depending on whether dt is before, equal to or
+// after other.
+func (dt DateTime) Compare(other DateTime) int {
+	return dt.In(time.UTC).Compare(other.In(time.UTC))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (dt *DateTime) UnmarshalText(data []byte) error {
+	var err error
+	*dt, err = ParseDateTime(string(data))
+	return err
+}
+
+// Scan implements sql.Scanner
+func (dt *DateTime) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*dt = DateTimeOf(v)
+		return nil
+	case string:
+		return dt.UnmarshalText([]byte(v))
+	case []byte:
+		return dt.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type civil.DateTime", src)
+}
+
+// Value implements driver.Valuer
+func (dt DateTime) Value() (driver.Value, error) {
+	return dt.String(), nil
+}
@@ -0,0 +1,106 @@
+package civil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2024-02-29")
+	if err != nil || d != (Date{2024, time.February, 29}) {
+		t.Fatalf("Unexpected parsed date: %v (%v)", d, err)
+	}
+	if !d.IsValid() || (Date{2023, time.February, 29}).IsValid() {
+		t.Errorf("Unexpected validity")
+	}
+	if next := d.AddDays(1); next.String() != "2024-03-01" || next.Compare(d) != 1 {
+		t.Errorf("Unexpected next day: %v", next)
+	}
+	if _, err := ParseDate("2024-02-30"); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+}
+
+func TestTime(t *testing.T) {
+	tm, err := ParseTime("09:05:07.1234")
+	if err != nil || tm != (Time{9, 5, 7, 123400000}) {
+		t.Fatalf("Unexpected parsed time: %v (%v)", tm, err)
+	}
+	if tm.String() != "09:05:07.1234" || (Time{23, 0, 0, 0}).String() != "23:00:00" {
+		t.Errorf("Unexpected formatting: %s", tm)
+	}
+	if !tm.IsValid() || (Time{Hour: 24}).IsValid() || tm.Compare(Time{Hour: 10}) != -1 {
+		t.Errorf("Unexpected validity or ordering")
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	for _, s := range []string{"2024-01-02T03:04:05.5", "2024-01-02 03:04:05.5"} {
+		dt, err := ParseDateTime(s)
+		if err != nil || dt.String() != "2024-01-02T03:04:05.5" {
+			t.Errorf("Unexpected parsed date time from %q: %v (%v)", s, dt, err)
+		}
+	}
+	if _, err := ParseDateTime("2024-01-02"); err == nil {
+		t.Errorf("Expected error without time of day")
+	}
+
+	loc := time.FixedZone("X", 3600)
+	dt := DateTimeOf(time.Date(2024, 1, 2, 3, 4, 5, 0, loc))
+	if got := dt.In(loc); !got.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, loc)) {
+		t.Errorf("Unexpected time: %s", got)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var d goption.Option[Date]
+	if err := d.Scan(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)); err != nil || d.Unwrap().String() != "2024-05-06" {
+		t.Errorf("Unexpected date from time: %v (%v)", d, err)
+	}
+	if err := d.Scan([]byte("2024-05-07")); err != nil || d.Unwrap().Day != 7 {
+		t.Errorf("Unexpected date from text: %v (%v)", d, err)
+	}
+	if v, err := d.Value(); err != nil || v != "2024-05-07" {
+		t.Errorf("Unexpected date value: %v (%v)", v, err)
+	}
+
+	var tm goption.Option[Time]
+	if err := tm.Scan("12:30:00"); err != nil || tm.Unwrap().Minute != 30 {
+		t.Errorf("Unexpected time from text: %v (%v)", tm, err)
+	}
+	if err := tm.Scan(int64(5)); err == nil {
+		t.Errorf("Expected error scanning an integer")
+	}
+
+	var dt goption.Option[DateTime]
+	if err := dt.Scan(nil); err != nil || dt.Ok() {
+		t.Errorf("Expected NULL to scan as None, got %v (%v)", dt, err)
+	}
+	if err := dt.Scan("2024-05-06 07:08:09"); err != nil || dt.Unwrap().Time.Second != 9 {
+		t.Errorf("Unexpected date time from text: %v (%v)", dt, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type event struct {
+		On goption.Option[Date]     `json:"on"`
+		At goption.Option[Time]     `json:"at"`
+		TS goption.Option[DateTime] `json:"ts"`
+	}
+
+	data, err := json.Marshal(event{On: goption.Some(Date{2024, 1, 2}), At: goption.Some(Time{Hour: 8})})
+	if err != nil || string(data) != `{"on":"2024-01-02","at":"08:00:00","ts":null}` {
+		t.Errorf("Unexpected JSON: %s (%v)", data, err)
+	}
+
+	var e event
+	if err := json.Unmarshal([]byte(`{"on":"2024-01-03","ts":"2024-01-03T04:05:06"}`), &e); err != nil {
+		t.Fatalf("Failed unmarshaling: %s", err)
+	}
+	if e.On.Unwrap().Day != 3 || e.At.Ok() || e.TS.Unwrap().Time.Hour != 4 {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+}
@@ -0,0 +1,94 @@
+// Command goption-gen generates code using goption. By default it generates
+// model structs for the tables of a PostgreSQL schema, with goption.Option
+// fields for nullable columns and functions scanning rows with
+// goption.ScanPlan. With -enum it instead generates Option codecs for
+// protobuf enum types, scanned from integer or name columns, written as
+// numbers and encoded to JSON as names.
+//
+// Usage:
+//
+//	goption-gen -dsn postgres://localhost/app -schema public -package models -out models/models.go
+//	goption-gen -enum github.com/acme/api/pb.Status,github.com/acme/api/pb.Role -package models -out models/enums.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olachat/goption/goptiongen"
+
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	dsn := flag.String("dsn", "", "PostgreSQL connection string")
+	schema := flag.String("schema", "public", "schema whose tables are generated")
+	enums := flag.String("enum", "", "comma separated protobuf enum types, as import path and type name, to generate Option codecs for instead of models")
+	pkg := flag.String("package", "models", "package name of the generated file")
+	out := flag.String("out", "", "file to write, standard output if empty")
+	flag.Parse()
+
+	var src bytes.Buffer
+	var err error
+	if *enums != "" {
+		err = generateEnums(&src, *enums, *pkg)
+	} else {
+		err = generateModels(&src, *dsn, *schema, *pkg)
+	}
+	if err == nil {
+		err = write(*out, src.Bytes())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "goption-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func generateModels(src *bytes.Buffer, dsn, schema, pkg string) error {
+	if dsn == "" {
+		return fmt.Errorf("-dsn or -enum is required")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	columns, err := goptiongen.LoadColumns(context.Background(), db, schema, "$1")
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("schema %q has no tables", schema)
+	}
+
+	return goptiongen.Generate(src, pkg, columns)
+}
+
+func generateEnums(src *bytes.Buffer, list, pkg string) error {
+	var enums []goptiongen.EnumType
+	for _, s := range strings.Split(list, ",") {
+		e, err := goptiongen.ParseEnumType(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		enums = append(enums, e)
+	}
+
+	return goptiongen.GenerateEnums(src, pkg, enums)
+}
+
+func write(out string, src []byte) error {
+	if out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,385 @@
+package goption
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/olachat/goption/convert"
+)
+
+// Codec holds opt-in settings which change how Options are scanned.
+// A nil *Codec behaves exactly like Option.Scan.
+type Codec struct {
+	intern      *internTable
+	jsonStructs bool
+	nullTokens  []string
+	epochUnit   time.Duration
+	normalize   []func(string) string
+
+	scanInterceptors  []Interceptor
+	valueInterceptors []Interceptor
+}
+
+// CodecOption configures a Codec.
+type CodecOption func(*Codec)
+
+// NewCodec returns a Codec configured by opts.
+func NewCodec(opts ...CodecOption) *Codec {
+	c := &Codec{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithInterning deduplicates identical strings scanned into string options,
+// which saves memory for low-cardinality text columns. At most maxEntries
+// distinct strings are interned; once the table is full other strings are
+// scanned as usual.
+func WithInterning(maxEntries int) CodecOption {
+	return func(c *Codec) {
+		c.intern = &internTable{
+			max:     maxEntries,
+			strings: make(map[string]string),
+		}
+	}
+}
+
+// WithJSONStructs decodes textual values which look like JSON objects with
+// encoding/json when scanning into struct options, e.g. json or jsonb columns
+// scanned into Option[MyStruct]. time.Time and types implementing
+// sql.Scanner are scanned as usual.
+func WithJSONStructs() CodecOption {
+	return func(c *Codec) {
+		c.jsonStructs = true
+	}
+}
+
+// DefaultNullToken is what FormatText emits for NULL unless configured
+// otherwise with WithNullTokens. It's the NULL of MySQL's LOAD DATA and
+// ClickHouse's TSV formats.
+const DefaultNullToken = `\N`
+
+// WithNullTokens makes textual values equal to one of tokens scan as NULL,
+// e.g. `\N`, "NULL" or "" when loading text files. The first token is what
+// FormatText emits for NULL.
+func WithNullTokens(tokens ...string) CodecOption {
+	return func(c *Codec) {
+		c.nullTokens = tokens
+	}
+}
+
+// isNull reports whether src is one of the null tokens of c.
+func (c *Codec) isNull(src any) bool {
+	if c == nil || len(c.nullTokens) == 0 {
+		return false
+	}
+
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return false
+	}
+
+	for _, token := range c.nullTokens {
+		if text == token {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatText formats v, usually an Option, for a text protocol. NULL is
+// formatted as the first token given to WithNullTokens, or DefaultNullToken.
+// Other values are formatted like they're scanned from text: times as
+// RFC 3339 and bytes as is.
+func (c *Codec) FormatText(v driver.Valuer) (string, error) {
+	value, err := v.Value()
+	if err != nil {
+		return "", err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		if c != nil && len(c.nullTokens) > 0 {
+			return c.nullTokens[0], nil
+		}
+		return DefaultNullToken, nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	}
+
+	return convert.AsString(value), nil
+}
+
+// WithEpochTime scans integer and floating point values into time options as
+// the number of units since the Unix epoch, e.g. time.Second for epoch
+// seconds or time.Millisecond for epoch millis. Fractions of a unit are
+// kept. Scanned times are in UTC. It panics if unit doesn't evenly divide a
+// second.
+func WithEpochTime(unit time.Duration) CodecOption {
+	if unit <= 0 || time.Second%unit != 0 {
+		panic(fmt.Sprintf("goption: invalid epoch unit %s", unit))
+	}
+
+	return func(c *Codec) {
+		c.epochUnit = unit
+	}
+}
+
+// epochTime stores src into dest as an epoch time if dest is a pointer to a
+// time.Time and src is numeric. It reports whether it assigned dest.
+func (c *Codec) epochTime(dest, src any) bool {
+	d, ok := dest.(*time.Time)
+	if !ok {
+		return false
+	}
+
+	var units int64
+	var frac float64
+	switch v := src.(type) {
+	case int64:
+		units = v
+	case float64:
+		whole, f := math.Modf(v)
+		if math.IsNaN(whole) || math.IsInf(whole, 0) || math.Abs(whole) >= math.MaxInt64 {
+			return false
+		}
+		units, frac = int64(whole), f
+	default:
+		return false
+	}
+
+	perSecond := int64(time.Second / c.epochUnit)
+	nsec := units%perSecond*int64(c.epochUnit) + int64(math.Round(frac*float64(c.epochUnit)))
+	*d = time.Unix(units/perSecond, nsec).UTC()
+	return true
+}
+
+// WithStringNormalizers applies normalizers, in order, to textual values
+// scanned into string options, including options of named string types.
+// E.g. strings.TrimSpace, strings.ToLower or norm.NFC.String of
+// golang.org/x/text/unicode/norm, for equivalent strings to scan to the same
+// bytes.
+func WithStringNormalizers(normalizers ...func(string) string) CodecOption {
+	return func(c *Codec) {
+		c.normalize = append(c.normalize, normalizers...)
+	}
+}
+
+// normalizeString returns src normalized if dest is a pointer to a string
+// kind and src is textual, otherwise src.
+func (c *Codec) normalizeString(dest, src any) any {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return src
+	}
+
+	if _, ok := dest.(*string); !ok {
+		dv := reflect.ValueOf(dest)
+		if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.String {
+			return src
+		}
+	}
+
+	for _, normalize := range c.normalize {
+		s = normalize(s)
+	}
+	return s
+}
+
+// codecScanner is implemented by *Option[T] to scan using a Codec.
+type codecScanner interface {
+	scanCodec(c *Codec, src any) error
+}
+
+// Scanner returns a sql.Scanner which scans into dest using c.
+// dest should be a pointer to an Option, other sql.Scanners are scanned as
+// usual.
+func (c *Codec) Scanner(dest any) sql.Scanner {
+	return codecTarget{codec: c, dest: dest}
+}
+
+type codecTarget struct {
+	codec  *Codec
+	dest   any
+	column string
+}
+
+// Scan implements sql.Scanner
+func (t codecTarget) Scan(src any) error {
+	switch d := t.dest.(type) {
+	case codecScanner:
+		if err := d.scanCodec(t.codec, src); err != nil {
+			return err
+		}
+		if opt, isOption := t.dest.(reflectOption); isOption && t.codec != nil && len(t.codec.scanInterceptors) > 0 {
+			return interceptOption(t.codec.scanInterceptors, t.column, opt)
+		}
+		return nil
+	case sql.Scanner:
+		return d.Scan(src)
+	}
+
+	return fmt.Errorf("unsupported Codec destination type %T", t.dest)
+}
+
+// convertAssign is like convert.Assign but applies the settings of c first.
+func (c *Codec) convertAssign(dest, src any) error {
+	if c == nil {
+		return convert.Assign(dest, src)
+	}
+
+	if len(c.normalize) > 0 {
+		src = c.normalizeString(dest, src)
+	}
+
+	if c.intern != nil {
+		if done := c.intern.assign(dest, src); done {
+			return nil
+		}
+	}
+
+	if c.epochUnit != 0 && c.epochTime(dest, src) {
+		return nil
+	}
+
+	if c.jsonStructs {
+		if data, ok := jsonObject(dest, src); ok {
+			return unmarshalFresh(data, dest)
+		}
+	}
+
+	return convert.Assign(dest, src)
+}
+
+// unmarshalFresh decodes data into a new value and assigns it to dest, a
+// pointer, so fields of a reused destination which are missing from data
+// don't survive.
+func unmarshalFresh(data []byte, dest any) error {
+	fresh := reflect.New(reflect.TypeOf(dest).Elem())
+	if err := json.Unmarshal(data, fresh.Interface()); err != nil {
+		return err
+	}
+
+	reflect.ValueOf(dest).Elem().Set(fresh.Elem())
+	return nil
+}
+
+// jsonObject returns src as JSON data if dest is a pointer to a plain struct
+// and src is text which looks like a JSON object.
+func jsonObject(dest, src any) ([]byte, bool) {
+	var data []byte
+	switch v := src.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, false
+	}
+
+	if _, isScanner := dest.(sql.Scanner); isScanner {
+		return nil, false
+	}
+	if _, isTime := dest.(*time.Time); isTime {
+		return nil, false
+	}
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) < 2 || trimmed[0] != '{' || trimmed[len(trimmed)-1] != '}' {
+		return nil, false
+	}
+
+	return trimmed, true
+}
+
+// internTable is a size-bounded string intern table.
+type internTable struct {
+	mu      sync.RWMutex
+	max     int
+	strings map[string]string
+}
+
+// assign stores the interned text of src into dest if dest is a pointer to a
+// string kind which isn't a sql.Scanner, whose Scan method must see src, and
+// src is textual. It reports whether it assigned dest.
+func (it *internTable) assign(dest, src any) bool {
+	if _, isScanner := dest.(sql.Scanner); isScanner {
+		return false
+	}
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.String {
+		return false
+	}
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = it.internString(v)
+	case []byte:
+		s = it.internBytes(v)
+	default:
+		return false
+	}
+
+	dv.Elem().SetString(s)
+	return true
+}
+
+func (it *internTable) internString(s string) string {
+	it.mu.RLock()
+	interned, ok := it.strings[s]
+	it.mu.RUnlock()
+	if ok {
+		return interned
+	}
+
+	return it.store(s)
+}
+
+func (it *internTable) internBytes(b []byte) string {
+	it.mu.RLock()
+	interned, ok := it.strings[string(b)]
+	it.mu.RUnlock()
+	if ok {
+		return interned
+	}
+
+	return it.store(string(b))
+}
+
+func (it *internTable) store(s string) string {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if interned, ok := it.strings[s]; ok {
+		return interned
+	}
+	if len(it.strings) >= it.max {
+		return s
+	}
+
+	it.strings[s] = s
+	return s
+}
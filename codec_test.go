@@ -0,0 +1,286 @@
+package goption
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type enumString string
+
+// stringData returns the address of the bytes backing s.
+func stringData(s string) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.StringData(s)))
+}
+
+func TestCodecInterning(t *testing.T) {
+	codec := NewCodec(WithInterning(2))
+
+	var a, b Option[string]
+	if err := codec.Scanner(&a).Scan([]byte("active")); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+	if err := codec.Scanner(&b).Scan([]byte("active")); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+	if a.Unwrap() != "active" || b.Unwrap() != "active" {
+		t.Fatalf("Unexpected scanned values: %v %v", a, b)
+	}
+	if stringData(a.Unwrap()) != stringData(b.Unwrap()) {
+		t.Errorf("Expected scanned strings to be interned")
+	}
+
+	var named Option[enumString]
+	if err := codec.Scanner(&named).Scan("inactive"); err != nil {
+		t.Fatalf("Failed scanning named string: %s", err)
+	}
+	if named.Unwrap() != "inactive" {
+		t.Errorf("Unexpected scanned value: %v", named)
+	}
+
+	// The table is full, other strings are scanned as usual.
+	var c, d Option[string]
+	codec.Scanner(&c).Scan([]byte("deleted"))
+	codec.Scanner(&d).Scan([]byte("deleted"))
+	if c.Unwrap() != "deleted" || d.Unwrap() != "deleted" {
+		t.Errorf("Unexpected scanned values: %v %v", c, d)
+	}
+	if stringData(c.Unwrap()) == stringData(d.Unwrap()) {
+		t.Errorf("Expected strings past the table limit not to be interned")
+	}
+}
+
+func TestCodecInterningSkipsOtherDestinations(t *testing.T) {
+	codec := NewCodec(WithInterning(1))
+
+	var i Option[int]
+	var raw Option[[]byte]
+	if err := codec.Scanner(&i).Scan([]byte("42")); err != nil || i.Unwrap() != 42 {
+		t.Fatalf("Failed scanning int: %v (%v)", i, err)
+	}
+	if err := codec.Scanner(&raw).Scan("blob"); err != nil || string(raw.Unwrap()) != "blob" {
+		t.Fatalf("Failed scanning bytes: %v (%v)", raw, err)
+	}
+	if len(codec.intern.strings) != 0 {
+		t.Errorf("Expected non-string destinations not to fill the table, got %v", codec.intern.strings)
+	}
+
+	var a, b Option[string]
+	codec.Scanner(&a).Scan([]byte("active"))
+	codec.Scanner(&b).Scan([]byte("active"))
+	if stringData(a.Unwrap()) != stringData(b.Unwrap()) {
+		t.Errorf("Expected the table to still have room for strings")
+	}
+}
+
+// upperString is a named string whose Scan method normalizes the scanned text.
+type upperString string
+
+func (u *upperString) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported upperString source %T", src)
+	}
+	*u = upperString(strings.ToUpper(s))
+	return nil
+}
+
+func TestCodecInterningSkipsScanners(t *testing.T) {
+	codec := NewCodec(WithInterning(10))
+
+	var u Option[upperString]
+	if err := codec.Scanner(&u).Scan("active"); err != nil || u.Unwrap() != "ACTIVE" {
+		t.Errorf("Expected the Scan method of the destination to run, got %v (%v)", u, err)
+	}
+	if len(codec.intern.strings) != 0 {
+		t.Errorf("Expected sql.Scanner destinations not to be interned, got %v", codec.intern.strings)
+	}
+}
+
+func TestCodecScannerFallback(t *testing.T) {
+	codec := NewCodec(WithInterning(10))
+
+	var i Option[int]
+	if err := codec.Scanner(&i).Scan(int64(3)); err != nil || i.Unwrap() != 3 {
+		t.Errorf("Failed scanning int: %v (%v)", i, err)
+	}
+
+	if err := codec.Scanner(&i).Scan(nil); err != nil || i.Ok() {
+		t.Errorf("Expected empty option, got %v (%v)", i, err)
+	}
+
+	var p Provenanced[string]
+	if err := codec.Scanner(&p).Scan("x"); err != nil || p.Unwrap() != "x" {
+		t.Errorf("Failed scanning into sql.Scanner: %v (%v)", p, err)
+	}
+
+	var nilCodec *Codec
+	var s Option[string]
+	if err := nilCodec.Scanner(&s).Scan("y"); err != nil || s.Unwrap() != "y" {
+		t.Errorf("Failed scanning with nil codec: %v (%v)", s, err)
+	}
+
+	var notScanner int
+	if err := codec.Scanner(&notScanner).Scan(int64(1)); err == nil {
+		t.Errorf("Expected error for unsupported destination")
+	}
+}
+
+type codecProfile struct {
+	Name Option[string] `json:"name"`
+	Age  Option[int]    `json:"age"`
+}
+
+func TestCodecJSONStructs(t *testing.T) {
+	codec := NewCodec(WithJSONStructs())
+
+	var profile Option[codecProfile]
+	if err := codec.Scanner(&profile).Scan([]byte(` {"name": "goption", "age": null} `)); err != nil {
+		t.Fatalf("Failed scanning JSON: %s", err)
+	}
+	if p := profile.Unwrap(); p.Name.Unwrap() != "goption" || p.Age.Ok() {
+		t.Errorf("Unexpected profile: %v", p)
+	}
+
+	if err := codec.Scanner(&profile).Scan(`{"name": `); err == nil {
+		t.Errorf("Expected error scanning malformed JSON")
+	}
+
+	var ts Option[time.Time]
+	now := time.Now()
+	if err := codec.Scanner(&ts).Scan(now); err != nil || !ts.Unwrap().Equal(now) {
+		t.Errorf("Expected time to be scanned as usual, got %v (%v)", ts, err)
+	}
+
+	// Strict codecs keep rejecting JSON text for structs.
+	if err := profile.Scan(`{"name": "goption"}`); err == nil {
+		t.Errorf("Expected error scanning JSON without WithJSONStructs")
+	}
+}
+
+func TestCodecJSONStructsReusedTarget(t *testing.T) {
+	codec := NewCodec(WithJSONStructs())
+
+	var profile Option[codecProfile]
+	for i, row := range []string{`{"name": "first", "age": 3}`, `{"name": "second"}`} {
+		if err := codec.Scanner(&profile).Scan(row); err != nil {
+			t.Fatalf("Failed scanning row %d: %s", i, err)
+		}
+	}
+	if p := profile.Unwrap(); p.Name.Unwrap() != "second" || p.Age.Ok() {
+		t.Errorf("Expected fields of the previous row not to survive, got %v", p)
+	}
+}
+
+func TestCodecNullTokens(t *testing.T) {
+	c := NewCodec(WithNullTokens(`\N`, "NULL", ""))
+
+	for _, src := range []any{`\N`, []byte("NULL"), ""} {
+		o := Some(5)
+		if err := c.Scanner(&o).Scan(src); err != nil || o.Ok() {
+			t.Errorf("Expected %q to scan as NULL, got %v (%v)", src, o, err)
+		}
+	}
+
+	var s Option[string]
+	if err := c.Scanner(&s).Scan("null"); err != nil || s.Unwrap() != "null" {
+		t.Errorf("Expected tokens to be case sensitive, got %v (%v)", s, err)
+	}
+	if err := s.Scan(`\N`); err != nil || s.Unwrap() != `\N` {
+		t.Errorf("Expected plain Scan to ignore tokens, got %v (%v)", s, err)
+	}
+
+	if text, err := c.FormatText(None[int]()); err != nil || text != `\N` {
+		t.Errorf("Expected first token for NULL, got %q (%v)", text, err)
+	}
+	if text, err := NewCodec(WithNullTokens("NULL")).FormatText(None[int]()); err != nil || text != "NULL" {
+		t.Errorf("Expected configured token for NULL, got %q (%v)", text, err)
+	}
+	if text, err := (*Codec)(nil).FormatText(None[int]()); err != nil || text != DefaultNullToken {
+		t.Errorf("Expected default token for NULL, got %q (%v)", text, err)
+	}
+	if text, err := c.FormatText(Some(1.5)); err != nil || text != "1.5" {
+		t.Errorf("Expected formatted float, got %q (%v)", text, err)
+	}
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	if text, err := c.FormatText(Some(at)); err != nil || text != "2024-05-06T07:08:09Z" {
+		t.Errorf("Expected formatted time, got %q (%v)", text, err)
+	}
+}
+
+func TestCodecEpochTime(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 12, 0, 0, 250000000, time.UTC)
+
+	cases := []struct {
+		unit time.Duration
+		src  any
+	}{
+		{time.Second, 1709294400.25},
+		{time.Millisecond, int64(1709294400250)},
+		{time.Millisecond, 1709294400250.0},
+		{time.Microsecond, int64(1709294400250000)},
+	}
+	for _, c := range cases {
+		var o Option[time.Time]
+		if err := NewCodec(WithEpochTime(c.unit)).Scanner(&o).Scan(c.src); err != nil {
+			t.Errorf("Failed scanning %v %s: %s", c.src, c.unit, err)
+			continue
+		}
+		if got := o.Unwrap(); !got.Equal(expected) || got.Location() != time.UTC {
+			t.Errorf("Expected %s from %v %s, got %s", expected, c.src, c.unit, got)
+		}
+	}
+
+	var before Option[time.Time]
+	if err := NewCodec(WithEpochTime(time.Second)).Scanner(&before).Scan(-1.5); err != nil || !before.Unwrap().Equal(time.Unix(-2, 500000000)) {
+		t.Errorf("Expected time before the epoch, got %v (%v)", before, err)
+	}
+
+	var n Option[int64]
+	if err := NewCodec(WithEpochTime(time.Second)).Scanner(&n).Scan(int64(5)); err != nil || n.Unwrap() != 5 {
+		t.Errorf("Expected non-time options to scan as usual, got %v (%v)", n, err)
+	}
+
+	var plain Option[time.Time]
+	if err := plain.Scan(int64(5)); err == nil {
+		t.Errorf("Expected plain Scan to reject numbers, got %v", plain)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for invalid unit")
+		}
+	}()
+	WithEpochTime(7 * time.Millisecond)
+}
+
+func TestCodecStringNormalizers(t *testing.T) {
+	c := NewCodec(WithStringNormalizers(strings.TrimSpace, strings.ToLower, norm.NFC.String))
+
+	var s Option[string]
+	if err := c.Scanner(&s).Scan([]byte("  Cafe\u0301 ")); err != nil || s.Unwrap() != "caf\u00e9" {
+		t.Errorf("Expected normalized string, got %q (%v)", s.UnwrapOrDefault(), err)
+	}
+
+	var e Option[enumString]
+	if err := c.Scanner(&e).Scan(" ACTIVE"); err != nil || e.Unwrap() != "active" {
+		t.Errorf("Expected normalized named string, got %q (%v)", e.UnwrapOrDefault(), err)
+	}
+
+	var b Option[[]byte]
+	if err := c.Scanner(&b).Scan([]byte(" RAW ")); err != nil || string(b.Unwrap()) != " RAW " {
+		t.Errorf("Expected bytes to be kept, got %q (%v)", b.UnwrapOrDefault(), err)
+	}
+
+	interned := NewCodec(WithStringNormalizers(strings.ToUpper), WithInterning(10))
+	var x, y Option[string]
+	_ = interned.Scanner(&x).Scan("abc")
+	_ = interned.Scanner(&y).Scan([]byte("ABC"))
+	if x.Unwrap() != "ABC" || stringData(x.Unwrap()) != stringData(y.Unwrap()) {
+		t.Errorf("Expected normalized strings to be interned together, got %q and %q", x.Unwrap(), y.Unwrap())
+	}
+}
@@ -0,0 +1,121 @@
+package goption
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// columnChunk is how many values a ConvertColumn worker converts at a time.
+const columnChunk = 1024
+
+// ColumnOption configures ConvertColumn.
+type ColumnOption func(*columnConfig)
+
+type columnConfig struct {
+	ctx     context.Context
+	workers int
+}
+
+// WithParallelism converts values using up to workers goroutines. Values are
+// converted sequentially by default.
+func WithParallelism(workers int) ColumnOption {
+	return func(c *columnConfig) {
+		c.workers = workers
+	}
+}
+
+// WithColumnContext stops ConvertColumn once ctx is done.
+func WithColumnContext(ctx context.Context) ColumnOption {
+	return func(c *columnConfig) {
+		c.ctx = ctx
+	}
+}
+
+// ConvertColumn applies f to the present values, keeping empty values
+// empty. It stops at the first error, which is returned with the index of the
+// failing value, or once the context given by WithColumnContext is done.
+// When converting in parallel, f must be safe for concurrent use and the
+// error of the failing value with the lowest index is returned.
+func ConvertColumn[T, U any](values []Option[T], f func(T) (U, error), opts ...ColumnOption) ([]Option[U], error) {
+	c := columnConfig{ctx: context.Background(), workers: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	out := make([]Option[U], len(values))
+	convertRange := func(start, end int) (int, error) {
+		for i := start; i < end; i++ {
+			if !values[i].ok {
+				continue
+			}
+			u, err := f(values[i].t)
+			if err != nil {
+				return i, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = Some(u)
+		}
+		return 0, nil
+	}
+
+	workers := min(c.workers, (len(values)+columnChunk-1)/columnChunk)
+	if workers <= 1 {
+		for start := 0; start < len(values); start += columnChunk {
+			if err := c.ctx.Err(); err != nil {
+				return nil, err
+			}
+			if _, err := convertRange(start, min(start+columnChunk, len(values))); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	var (
+		next     atomic.Int64
+		stop     atomic.Bool
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		errIndex int
+	)
+	fail := func(i int, err error) {
+		stop.Store(true)
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil || i < errIndex {
+			firstErr, errIndex = err, i
+		}
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				start := int(next.Add(columnChunk)) - columnChunk
+				if start >= len(values) {
+					return
+				}
+				if c.ctx.Err() != nil {
+					stop.Store(true)
+					return
+				}
+				if i, err := convertRange(start, min(start+columnChunk, len(values))); err != nil {
+					fail(i, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if stop.Load() {
+		return nil, c.ctx.Err()
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package goption
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestConvertColumn(t *testing.T) {
+	values := make([]Option[int], 5000)
+	for i := range values {
+		if i%3 != 0 {
+			values[i] = Some(i)
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		out, err := ConvertColumn(values, func(i int) (string, error) {
+			return strconv.Itoa(i), nil
+		}, WithParallelism(workers))
+		if err != nil {
+			t.Fatalf("Failed converting with %d workers: %s", workers, err)
+		}
+		if len(out) != len(values) {
+			t.Fatalf("Expected %d values, got %d", len(values), len(out))
+		}
+		for i, o := range out {
+			if o.Ok() != values[i].Ok() || (o.Ok() && o.Unwrap() != strconv.Itoa(i)) {
+				t.Errorf("Expected %v at %d with %d workers, got %v", values[i], i, workers, o)
+				break
+			}
+		}
+	}
+
+	if out, err := ConvertColumn([]Option[int]{}, func(i int) (int, error) { return i, nil }); err != nil || len(out) != 0 {
+		t.Errorf("Expected empty result, got %v (%v)", out, err)
+	}
+}
+
+func TestConvertColumnError(t *testing.T) {
+	values := make([]Option[int], 5000)
+	for i := range values {
+		values[i] = Some(i)
+	}
+	failure := errors.New("failure")
+	f := func(i int) (int, error) {
+		if i == 1500 || i == 4000 {
+			return 0, failure
+		}
+		return i, nil
+	}
+
+	for _, workers := range []int{1, 4} {
+		out, err := ConvertColumn(values, f, WithParallelism(workers))
+		if !errors.Is(err, failure) || out != nil {
+			t.Errorf("Expected failure with %d workers, got %v", workers, err)
+		}
+		if err != nil && err.Error() != "[1500]: failure" {
+			t.Errorf("Expected error of the first failing value with %d workers, got %v", workers, err)
+		}
+	}
+}
+
+func TestConvertColumnCancel(t *testing.T) {
+	values := make([]Option[int], 5000)
+	for i := range values {
+		values[i] = Some(i)
+	}
+
+	for _, workers := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		_, err := ConvertColumn(values, func(i int) (int, error) {
+			if workers == 1 {
+				calls++
+			}
+			cancel()
+			return i, nil
+		}, WithParallelism(workers), WithColumnContext(ctx))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled with %d workers, got %v", workers, err)
+		}
+		if workers == 1 && calls != columnChunk {
+			t.Errorf("Expected conversion to stop after the first chunk, got %d calls", calls)
+		}
+	}
+}
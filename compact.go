@@ -0,0 +1,418 @@
+package goption
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var errCompactTruncated = errors.New("compact data is truncated")
+
+// EncodeCompact encodes v, a struct or pointer to a struct, into a compact
+// binary form. Option fields are represented by a presence bitmap followed by
+// only the present values, which is much smaller than JSON for structs made
+// mostly of Option fields.
+//
+// Only exported fields are encoded, and empty slices and maps decode as nil.
+// Supported field types are booleans, numbers, strings, byte slices, slices,
+// maps, pointers, nested structs, Options of those, and types implementing
+// encoding.BinaryMarshaler. Map entries are sorted by their encoded keys, so
+// equal values encode to equal bytes.
+func EncodeCompact(v any) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported compact type %T, must be a struct", v)
+	}
+
+	return appendCompactStruct(nil, addressable(rv))
+}
+
+// DecodeCompact decodes data produced by EncodeCompact into dst, which must
+// be a pointer to a struct of the encoded type.
+func DecodeCompact(data []byte, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported compact destination %T, must be a pointer to a struct", dst)
+	}
+
+	rest, err := decodeCompactStruct(data, rv.Elem())
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("compact data has %d trailing bytes", len(rest))
+	}
+
+	return nil
+}
+
+// compactFields describes how a struct type is laid out in compact form.
+type compactFields struct {
+	fields []int
+	// options lists, for each field, its bit in the presence bitmap or -1 if
+	// it isn't an Option.
+	options   []int
+	numOption int
+}
+
+var compactFieldCache sync.Map // map[reflect.Type]*compactFields
+
+func compactFieldsOf(t reflect.Type) *compactFields {
+	if cached, ok := compactFieldCache.Load(t); ok {
+		return cached.(*compactFields)
+	}
+
+	cf := &compactFields{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		cf.fields = append(cf.fields, i)
+		if isOptionType(f.Type) {
+			cf.options = append(cf.options, cf.numOption)
+			cf.numOption++
+		} else {
+			cf.options = append(cf.options, -1)
+		}
+	}
+
+	cached, _ := compactFieldCache.LoadOrStore(t, cf)
+	return cached.(*compactFields)
+}
+
+func appendCompactStruct(buf []byte, rv reflect.Value) ([]byte, error) {
+	cf := compactFieldsOf(rv.Type())
+
+	bitmapStart := len(buf)
+	buf = append(buf, make([]byte, (cf.numOption+7)/8)...)
+
+	var err error
+	for i, index := range cf.fields {
+		fv := rv.Field(index)
+		bit := cf.options[i]
+		if bit < 0 {
+			if buf, err = appendCompactValue(buf, fv); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		opt, _ := asReflectOption(fv)
+		val, ok := opt.reflectGet()
+		if !ok {
+			continue
+		}
+
+		buf[bitmapStart+bit/8] |= 1 << (bit % 8)
+		if buf, err = appendCompactValue(buf, val); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func decodeCompactStruct(data []byte, rv reflect.Value) ([]byte, error) {
+	cf := compactFieldsOf(rv.Type())
+
+	bitmapLen := (cf.numOption + 7) / 8
+	if len(data) < bitmapLen {
+		return nil, errCompactTruncated
+	}
+	bitmap, data := data[:bitmapLen], data[bitmapLen:]
+
+	var err error
+	for i, index := range cf.fields {
+		fv := rv.Field(index)
+		bit := cf.options[i]
+		if bit < 0 {
+			if data, err = decodeCompactValue(data, fv); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		opt, _ := asReflectOption(fv)
+		if bitmap[bit/8]&(1<<(bit%8)) == 0 {
+			opt.reflectClear()
+			continue
+		}
+
+		if data, err = decodeCompactValue(data, opt.reflectSet()); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+var binaryMarshalerType = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+var binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+
+func appendCompactBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+func appendCompactValue(buf []byte, rv reflect.Value) ([]byte, error) {
+	rv = addressable(rv)
+
+	if opt, isOption := asReflectOption(rv); isOption {
+		val, ok := opt.reflectGet()
+		if !ok {
+			return append(buf, 0), nil
+		}
+		return appendCompactValue(append(buf, 1), val)
+	}
+
+	if rv.Type().Implements(binaryMarshalerType) {
+		b, err := rv.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendCompactBytes(buf, b), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(buf, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(buf, rv.Uint()), nil
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(rv.Float()))), nil
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(rv.Float())), nil
+	case reflect.String:
+		return appendCompactBytes(buf, []byte(rv.String())), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return appendCompactBytes(buf, rv.Bytes()), nil
+		}
+		buf = binary.AppendUvarint(buf, uint64(rv.Len()))
+		var err error
+		for i := 0; i < rv.Len(); i++ {
+			if buf, err = appendCompactValue(buf, rv.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Map:
+		return appendCompactMap(buf, rv)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return append(buf, 0), nil
+		}
+		return appendCompactValue(append(buf, 1), rv.Elem())
+	case reflect.Struct:
+		return appendCompactStruct(buf, rv)
+	}
+
+	return nil, fmt.Errorf("unsupported compact type %s", rv.Type())
+}
+
+// appendCompactMap appends the entries of rv sorted by their encoded keys.
+func appendCompactMap(buf []byte, rv reflect.Value) ([]byte, error) {
+	type entry struct {
+		key, value []byte
+	}
+
+	entries := make([]entry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := appendCompactValue(nil, iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := appendCompactValue(nil, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{key: key, value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	buf = binary.AppendUvarint(buf, uint64(len(entries)))
+	for _, e := range entries {
+		buf = append(append(buf, e.key...), e.value...)
+	}
+	return buf, nil
+}
+
+func decodeCompactBytes(data []byte) ([]byte, []byte, error) {
+	n, read := binary.Uvarint(data)
+	if read <= 0 || uint64(len(data)-read) < n {
+		return nil, nil, errCompactTruncated
+	}
+
+	data = data[read:]
+	return data[:n], data[n:], nil
+}
+
+func decodeCompactByte(data []byte) (byte, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, errCompactTruncated
+	}
+
+	return data[0], data[1:], nil
+}
+
+func decodeCompactValue(data []byte, rv reflect.Value) ([]byte, error) {
+	if opt, isOption := asReflectOption(rv); isOption {
+		present, data, err := decodeCompactByte(data)
+		if err != nil {
+			return nil, err
+		}
+		if present == 0 {
+			opt.reflectClear()
+			return data, nil
+		}
+		return decodeCompactValue(data, opt.reflectSet())
+	}
+
+	if reflect.PointerTo(rv.Type()).Implements(binaryUnmarshalerType) {
+		b, data, err := decodeCompactBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return data, rv.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, data, err := decodeCompactByte(data)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetBool(b != 0)
+		return data, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, read := binary.Varint(data)
+		if read <= 0 {
+			return nil, errCompactTruncated
+		}
+		if rv.OverflowInt(i) {
+			return nil, fmt.Errorf("compact value %d overflows %s", i, rv.Type())
+		}
+		rv.SetInt(i)
+		return data[read:], nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, read := binary.Uvarint(data)
+		if read <= 0 {
+			return nil, errCompactTruncated
+		}
+		if rv.OverflowUint(u) {
+			return nil, fmt.Errorf("compact value %d overflows %s", u, rv.Type())
+		}
+		rv.SetUint(u)
+		return data[read:], nil
+	case reflect.Float32:
+		if len(data) < 4 {
+			return nil, errCompactTruncated
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+		return data[4:], nil
+	case reflect.Float64:
+		if len(data) < 8 {
+			return nil, errCompactTruncated
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return data[8:], nil
+	case reflect.String:
+		b, data, err := decodeCompactBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetString(string(b))
+		return data, nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b, data, err := decodeCompactBytes(data)
+			if err != nil {
+				return nil, err
+			}
+			if len(b) == 0 {
+				rv.SetZero()
+			} else {
+				rv.SetBytes(append([]byte(nil), b...))
+			}
+			return data, nil
+		}
+		n, read := binary.Uvarint(data)
+		if read <= 0 || n > uint64(len(data)) {
+			return nil, errCompactTruncated
+		}
+		data = data[read:]
+		if n == 0 {
+			rv.SetZero()
+			return data, nil
+		}
+		slice := reflect.MakeSlice(rv.Type(), int(n), int(n))
+		var err error
+		for i := 0; i < int(n); i++ {
+			if data, err = decodeCompactValue(data, slice.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		rv.Set(slice)
+		return data, nil
+	case reflect.Map:
+		n, read := binary.Uvarint(data)
+		if read <= 0 || n > uint64(len(data)) {
+			return nil, errCompactTruncated
+		}
+		data = data[read:]
+		if n == 0 {
+			rv.SetZero()
+			return data, nil
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), int(n))
+		var err error
+		for i := 0; i < int(n); i++ {
+			key := reflect.New(rv.Type().Key()).Elem()
+			if data, err = decodeCompactValue(data, key); err != nil {
+				return nil, err
+			}
+			val := reflect.New(rv.Type().Elem()).Elem()
+			if data, err = decodeCompactValue(data, val); err != nil {
+				return nil, err
+			}
+			m.SetMapIndex(key, val)
+		}
+		rv.Set(m)
+		return data, nil
+	case reflect.Pointer:
+		present, data, err := decodeCompactByte(data)
+		if err != nil {
+			return nil, err
+		}
+		if present == 0 {
+			rv.SetZero()
+			return data, nil
+		}
+		elem := reflect.New(rv.Type().Elem())
+		if data, err = decodeCompactValue(data, elem.Elem()); err != nil {
+			return nil, err
+		}
+		rv.Set(elem)
+		return data, nil
+	case reflect.Struct:
+		return decodeCompactStruct(data, rv)
+	}
+
+	return nil, fmt.Errorf("unsupported compact type %s", rv.Type())
+}
@@ -0,0 +1,120 @@
+package goption
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type compactAddress struct {
+	City Option[string]
+	Zip  Option[int]
+}
+
+type compactDTO struct {
+	ID        int64
+	Name      Option[string]
+	Nickname  Option[string]
+	Age       Option[uint8]
+	Score     Option[float64]
+	Active    Option[bool]
+	CreatedAt Option[time.Time]
+	Tags      Option[[]string]
+	Avatar    Option[[]byte]
+	Address   Option[compactAddress]
+	Extra     map[string]Option[int]
+	Parent    *compactDTO
+	ignored   int
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	dto := compactDTO{
+		ID:        7,
+		Name:      Some("goption"),
+		Score:     Some(2.5),
+		Active:    Some(false),
+		CreatedAt: Some(time.Date(2024, 2, 16, 12, 0, 0, 0, time.UTC)),
+		Tags:      Some([]string{"a", "b"}),
+		Avatar:    Some([]byte{1, 2, 3}),
+		Address:   Some(compactAddress{City: Some("Singapore")}),
+		Extra:     map[string]Option[int]{"x": Some(1), "y": None[int]()},
+		Parent:    &compactDTO{ID: 1},
+	}
+
+	encoded, err := EncodeCompact(dto)
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+
+	var decoded compactDTO
+	if err := DecodeCompact(encoded, &decoded); err != nil {
+		t.Fatalf("Failed decoding: %s", err)
+	}
+	if !reflect.DeepEqual(dto, decoded) {
+		t.Errorf("Round trip mismatch:\n%#v\n%#v", dto, decoded)
+	}
+
+	asJSON, _ := json.Marshal(dto)
+	if len(encoded) >= len(asJSON) {
+		t.Errorf("Expected compact encoding (%d bytes) to be smaller than JSON (%d bytes)", len(encoded), len(asJSON))
+	}
+}
+
+func TestCompactEmpty(t *testing.T) {
+	encoded, err := EncodeCompact(&compactAddress{})
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	if len(encoded) != 1 {
+		t.Errorf("Expected only the presence bitmap, got %v", encoded)
+	}
+
+	decoded := compactAddress{City: Some("stale")}
+	if err := DecodeCompact(encoded, &decoded); err != nil {
+		t.Fatalf("Failed decoding: %s", err)
+	}
+	if decoded.City.Ok() || decoded.Zip.Ok() {
+		t.Errorf("Expected empty options, got %v", decoded)
+	}
+}
+
+func TestCompactDeterministicMaps(t *testing.T) {
+	dto := compactDTO{Extra: make(map[string]Option[int])}
+	for i := 0; i < 50; i++ {
+		dto.Extra[string(rune('a'+i))] = Some(i)
+	}
+
+	first, err := EncodeCompact(dto)
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	for i := 0; i < 20; i++ {
+		if encoded, err := EncodeCompact(dto); err != nil || !bytes.Equal(encoded, first) {
+			t.Fatalf("Expected the same bytes for every encoding, got %v (%v)", encoded, err)
+		}
+	}
+}
+
+func TestCompactErrors(t *testing.T) {
+	if _, err := EncodeCompact(3); err == nil {
+		t.Errorf("Expected error encoding non-struct")
+	}
+
+	if _, err := EncodeCompact(struct{ C Option[chan int] }{C: Some(make(chan int))}); err == nil {
+		t.Errorf("Expected error encoding unsupported type")
+	}
+
+	encoded, _ := EncodeCompact(compactAddress{City: Some("Singapore")})
+	var decoded compactAddress
+	if err := DecodeCompact(encoded[:len(encoded)-1], &decoded); err == nil {
+		t.Errorf("Expected error decoding truncated data")
+	}
+	if err := DecodeCompact(append(encoded, 0), &decoded); err == nil {
+		t.Errorf("Expected error decoding trailing data")
+	}
+	if err := DecodeCompact(encoded, decoded); err == nil {
+		t.Errorf("Expected error decoding into non-pointer")
+	}
+}
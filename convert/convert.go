@@ -0,0 +1,420 @@
+// Package convert implements the conversions database/sql applies when
+// scanning driver values, for use outside of sql.Scanner implementations.
+package convert
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNilPtr = errors.New("destination pointer is nil") // embedded in descriptive error
+
+// ErrCursorUnsupported is returned by Assign when src is a cursor, a nested
+// driver.Rows as returned for REF CURSOR columns, and dest can't hold it.
+// Scan cursors into *any or a sql.Scanner which handles driver.Rows.
+var ErrCursorUnsupported = errors.New("scanning cursor values is unsupported")
+
+// DecimalDecomposer is implemented by decimal types which can be sent to drivers
+// losslessly, like the decimalDecompose interface of database/sql.
+type DecimalDecomposer interface {
+	// Decompose returns the internal decimal state in parts.
+	// If the provided buf has sufficient capacity, buf may be returned as the coefficient with
+	// the value set and length set as appropriate.
+	Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32)
+}
+
+// DecimalComposer is implemented by decimal types which can be scanned
+// losslessly, like the decimalCompose interface of database/sql.
+type DecimalComposer interface {
+	// Compose sets the internal decimal value from parts. If the value cannot be
+	// represented then an error should be returned.
+	Compose(form byte, negative bool, coefficient []byte, exponent int32) error
+}
+
+// RawBytes is a byte slice which may alias memory owned by the driver, like
+// sql.RawBytes. Assign doesn't copy into RawBytes destinations.
+type RawBytes []byte
+
+// Assign stores src, a value returned by a driver, into dest, which must be a
+// non-nil pointer, converting it like sql.Rows.Scan does. Destinations
+// implementing sql.Scanner are scanned, *any receives src as is with byte
+// slices copied, and src is converted to the type of other destinations,
+// e.g. text is parsed into numbers. An error is returned if the conversion
+// would lose information, and ErrCursorUnsupported if src is a cursor.
+func Assign(dest, src any) error {
+	// Common cases, without reflect.
+	switch s := src.(type) {
+	case string:
+		switch d := dest.(type) {
+		case *string:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = s
+			return nil
+		case *[]byte:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = []byte(s)
+			return nil
+		case *RawBytes:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = append((*d)[:0], s...)
+			return nil
+		}
+	case []byte:
+		switch d := dest.(type) {
+		case *string:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = string(s)
+			return nil
+		case *any:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = bytes.Clone(s)
+			return nil
+		case *[]byte:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = bytes.Clone(s)
+			return nil
+		case *RawBytes:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = s
+			return nil
+		}
+	case time.Time:
+		switch d := dest.(type) {
+		case *time.Time:
+			*d = s
+			return nil
+		case *string:
+			*d = s.Format(time.RFC3339Nano)
+			return nil
+		case *[]byte:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = []byte(s.Format(time.RFC3339Nano))
+			return nil
+		case *RawBytes:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = s.AppendFormat((*d)[:0], time.RFC3339Nano)
+			return nil
+		}
+	case DecimalDecomposer:
+		switch d := dest.(type) {
+		case DecimalComposer:
+			return d.Compose(s.Decompose(nil))
+		}
+	case nil:
+		switch d := dest.(type) {
+		case *any:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = nil
+			return nil
+		case *[]byte:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = nil
+			return nil
+		case *RawBytes:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = nil
+			return nil
+		}
+	}
+
+	var sv reflect.Value
+
+	switch d := dest.(type) {
+	case *string:
+		sv = reflect.ValueOf(src)
+		switch sv.Kind() {
+		case reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			*d = AsString(src)
+			return nil
+		}
+	case *[]byte:
+		sv = reflect.ValueOf(src)
+		if b, ok := asBytes(nil, sv); ok {
+			*d = b
+			return nil
+		}
+	case *RawBytes:
+		sv = reflect.ValueOf(src)
+		if b, ok := asBytes([]byte(*d)[:0], sv); ok {
+			*d = RawBytes(b)
+			return nil
+		}
+	case *bool:
+		bv, err := driver.Bool.ConvertValue(src)
+		if err == nil {
+			*d = bv.(bool)
+		}
+		return err
+	case *any:
+		*d = src
+		return nil
+	}
+
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+
+	if decimal, ok := dest.(DecimalComposer); ok {
+		switch src.(type) {
+		case string, []byte, int64, float64:
+			return composeDecimal(decimal, AsString(src))
+		}
+	}
+
+	if _, isCursor := src.(driver.Rows); isCursor {
+		return fmt.Errorf("storing driver.Value type %T into type %T: %w", src, dest, ErrCursorUnsupported)
+	}
+
+	switch d := dest.(type) {
+	case *netip.AddrPort:
+		return assignAddrPort(d, src)
+	case *net.HardwareAddr:
+		return assignHardwareAddr(d, src)
+	}
+
+	dpv := reflect.ValueOf(dest)
+	if dpv.Kind() != reflect.Pointer {
+		return errors.New("destination not a pointer")
+	}
+	if dpv.IsNil() {
+		return errNilPtr
+	}
+
+	if !sv.IsValid() {
+		sv = reflect.ValueOf(src)
+	}
+
+	dv := reflect.Indirect(dpv)
+	if sv.IsValid() && sv.Type().AssignableTo(dv.Type()) {
+		switch b := src.(type) {
+		case []byte:
+			dv.Set(reflect.ValueOf(bytes.Clone(b)))
+		default:
+			dv.Set(sv)
+		}
+		return nil
+	}
+
+	if dv.Kind() == sv.Kind() && sv.Type().ConvertibleTo(dv.Type()) {
+		dv.Set(sv.Convert(dv.Type()))
+		return nil
+	}
+
+	// The following conversions use a string value as an intermediate representation
+	// to convert between various numeric types.
+	//
+	// This also allows scanning into user defined types such as "type Int int64".
+	// For symmetry, also check for string destination types.
+	switch dv.Kind() {
+	case reflect.Pointer:
+		if src == nil {
+			dv.SetZero()
+			return nil
+		}
+		dv.Set(reflect.New(dv.Type().Elem()))
+		return Assign(dv.Interface(), src)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if src == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
+		}
+		var buf [64]byte
+		text := AppendString(buf[:0], src)
+		i64, err := strconv.ParseInt(string(text), 10, dv.Type().Bits())
+		if err != nil {
+			err = strconvErr(err)
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, string(text), dv.Kind(), err)
+		}
+		dv.SetInt(i64)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if src == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
+		}
+		var buf [64]byte
+		text := AppendString(buf[:0], src)
+		u64, err := strconv.ParseUint(string(text), 10, dv.Type().Bits())
+		if err != nil {
+			err = strconvErr(err)
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, string(text), dv.Kind(), err)
+		}
+		dv.SetUint(u64)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if src == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
+		}
+		var buf [64]byte
+		text := AppendString(buf[:0], src)
+		f64, err := strconv.ParseFloat(string(text), dv.Type().Bits())
+		if err != nil {
+			err = strconvErr(err)
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, string(text), dv.Kind(), err)
+		}
+		dv.SetFloat(f64)
+		return nil
+	case reflect.String:
+		if src == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
+		}
+		switch v := src.(type) {
+		case string:
+			dv.SetString(v)
+			return nil
+		case []byte:
+			dv.SetString(string(v))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+}
+
+// composeDecimal parses the textual decimal s, e.g. "-12.50", "1e-3" or
+// "NaN", into d.
+func composeDecimal(d DecimalComposer, s string) error {
+	var negative bool
+	text := s
+	if len(text) > 0 && (text[0] == '-' || text[0] == '+') {
+		negative = text[0] == '-'
+		text = text[1:]
+	}
+
+	switch strings.ToLower(text) {
+	case "nan":
+		return d.Compose(2, false, nil, 0)
+	case "inf", "infinity":
+		return d.Compose(1, negative, nil, 0)
+	}
+
+	var exponent int64
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(text[i+1:], 10, 32)
+		if err != nil {
+			return fmt.Errorf("converting %q to a decimal: %v", s, strconvErr(err))
+		}
+		exponent, text = e, text[:i]
+	}
+
+	digits := text
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		digits = text[:i] + text[i+1:]
+		exponent -= int64(len(text) - i - 1)
+	}
+
+	coefficient, ok := new(big.Int).SetString(digits, 10)
+	if !ok || coefficient.Sign() < 0 || strings.ContainsAny(digits, "+-") {
+		return fmt.Errorf("converting %q to a decimal: invalid syntax", s)
+	}
+	if exponent < math.MinInt32 || exponent > math.MaxInt32 {
+		return fmt.Errorf("converting %q to a decimal: exponent out of range", s)
+	}
+
+	return d.Compose(0, negative, coefficient.Bytes(), int32(exponent))
+}
+
+func strconvErr(err error) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		return ne.Err
+	}
+	return err
+}
+
+// AsString returns the textual representation of src, as used when
+// converting driver values through strings.
+func AsString(src any) string {
+	switch v := src.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	var buf [64]byte
+	return string(AppendString(buf[:0], src))
+}
+
+// AppendString appends the textual representation of src, as returned by
+// AsString, to dst and returns the extended buffer.
+func AppendString(dst []byte, src any) []byte {
+	switch v := src.(type) {
+	case string:
+		return append(dst, v...)
+	case []byte:
+		return append(dst, v...)
+	}
+
+	rv := reflect.ValueOf(src)
+	if b, ok := asBytes(dst, rv); ok {
+		return b
+	}
+	return fmt.Appendf(dst, "%v", src)
+}
+
+// AsBytes returns the textual representation of src as bytes if src is a
+// boolean, number or string.
+func AsBytes(src any) ([]byte, bool) {
+	return AppendBytes(nil, src)
+}
+
+// AppendBytes appends the textual representation of src to dst if src is a
+// boolean, number or string, like AsBytes, and returns the extended buffer.
+func AppendBytes(dst []byte, src any) ([]byte, bool) {
+	return asBytes(dst, reflect.ValueOf(src))
+}
+
+func asBytes(buf []byte, rv reflect.Value) (b []byte, ok bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(buf, rv.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.AppendFloat(buf, rv.Float(), 'g', -1, 32), true
+	case reflect.Float64:
+		return strconv.AppendFloat(buf, rv.Float(), 'g', -1, 64), true
+	case reflect.Bool:
+		return strconv.AppendBool(buf, rv.Bool()), true
+	case reflect.String:
+		s := rv.String()
+		return append(buf, s...), true
+	}
+	return
+}
@@ -0,0 +1,152 @@
+package convert
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+type namedInt int64
+
+func TestAssign(t *testing.T) {
+	var s string
+	if err := Assign(&s, []byte("hello")); err != nil || s != "hello" {
+		t.Errorf("Failed assigning bytes to string: %q (%v)", s, err)
+	}
+
+	var i int32
+	if err := Assign(&i, "42"); err != nil || i != 42 {
+		t.Errorf("Failed assigning string to int32: %d (%v)", i, err)
+	}
+	if err := Assign(&i, "99999999999"); err == nil {
+		t.Errorf("Expected error assigning out of range value")
+	}
+
+	var n namedInt
+	if err := Assign(&n, int64(7)); err != nil || n != 7 {
+		t.Errorf("Failed assigning to named type: %d (%v)", n, err)
+	}
+
+	var b bool
+	if err := Assign(&b, int64(1)); err != nil || !b {
+		t.Errorf("Failed assigning int to bool: %v (%v)", b, err)
+	}
+
+	var raw RawBytes
+	src := []byte("raw")
+	if err := Assign(&raw, src); err != nil || &raw[0] != &src[0] {
+		t.Errorf("Expected RawBytes to alias the source, got %q (%v)", raw, err)
+	}
+
+	now := time.Now()
+	var ts time.Time
+	if err := Assign(&ts, now); err != nil || !ts.Equal(now) {
+		t.Errorf("Failed assigning time: %v (%v)", ts, err)
+	}
+
+	if err := Assign(&ts, "not a time"); err == nil {
+		t.Errorf("Expected error assigning string to time")
+	}
+
+	var nilDest *string
+	if err := Assign(nilDest, "x"); err == nil {
+		t.Errorf("Expected error assigning to nil pointer")
+	}
+}
+
+func TestAsString(t *testing.T) {
+	cases := []struct {
+		src      any
+		expected string
+	}{
+		{"s", "s"},
+		{[]byte("b"), "b"},
+		{int8(-3), "-3"},
+		{uint16(3), "3"},
+		{float32(1.5), "1.5"},
+		{true, "true"},
+		{struct{}{}, "{}"},
+	}
+	for _, c := range cases {
+		if str := AsString(c.src); str != c.expected {
+			t.Errorf("Expected %q for %v, got %q", c.expected, c.src, str)
+		}
+	}
+}
+
+func TestAsBytes(t *testing.T) {
+	if b, ok := AsBytes(int64(12)); !ok || string(b) != "12" {
+		t.Errorf("Expected 12, got %q (%v)", b, ok)
+	}
+
+	if _, ok := AsBytes([]int{1}); ok {
+		t.Errorf("Expected slices not to be converted")
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	buf := []byte("x=")
+	if b := AppendString(buf, int8(-3)); string(b) != "x=-3" {
+		t.Errorf("Expected x=-3, got %q", b)
+	}
+	if b := AppendString(nil, []byte("raw")); string(b) != "raw" {
+		t.Errorf("Expected raw, got %q", b)
+	}
+	if b := AppendString(nil, []int{1}); string(b) != "[1]" {
+		t.Errorf("Expected fmt fallback, got %q", b)
+	}
+	if b, ok := AppendBytes([]byte("n:"), 1.5); !ok || string(b) != "n:1.5" {
+		t.Errorf("Expected n:1.5, got %q", b)
+	}
+	if _, ok := AppendBytes(nil, struct{}{}); ok {
+		t.Errorf("Expected unsupported type to fail")
+	}
+}
+
+func TestAssignAllocs(t *testing.T) {
+	var i int32
+	var u uint16
+	var f float32
+	cases := []any{[]byte("123"), int64(45)}
+	for _, src := range cases {
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := Assign(&i, src); err != nil {
+				t.Fatal(err)
+			}
+			if err := Assign(&u, src); err != nil {
+				t.Fatal(err)
+			}
+			if err := Assign(&f, src); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("Expected no allocations converting %T, got %v", src, allocs)
+		}
+	}
+}
+
+type fakeCursor struct{}
+
+func (fakeCursor) Columns() []string              { return []string{"id"} }
+func (fakeCursor) Close() error                   { return nil }
+func (fakeCursor) Next(dest []driver.Value) error { return io.EOF }
+
+func TestAssignCursor(t *testing.T) {
+	var i int
+	if err := Assign(&i, fakeCursor{}); !errors.Is(err, ErrCursorUnsupported) {
+		t.Errorf("Expected ErrCursorUnsupported, got %v", err)
+	}
+
+	var s string
+	if err := Assign(&s, fakeCursor{}); !errors.Is(err, ErrCursorUnsupported) {
+		t.Errorf("Expected ErrCursorUnsupported, got %v", err)
+	}
+
+	var a any
+	if err := Assign(&a, fakeCursor{}); err != nil || a != (fakeCursor{}) {
+		t.Errorf("Expected cursor to be stored in any, got %v (%v)", a, err)
+	}
+}
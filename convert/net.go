@@ -0,0 +1,82 @@
+package convert
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// assignAddrPort converts textual forms like "10.0.0.1:5432" and "[::1]:80",
+// as well as the netip.AddrPort binary encoding, into d.
+func assignAddrPort(d *netip.AddrPort, src any) error {
+	switch s := src.(type) {
+	case string:
+		ap, err := netip.ParseAddrPort(s)
+		if err != nil {
+			return fmt.Errorf("converting %q to netip.AddrPort: %w", s, err)
+		}
+		*d = ap
+		return nil
+	case []byte:
+		if ap, err := netip.ParseAddrPort(string(s)); err == nil {
+			*d = ap
+			return nil
+		}
+		var ap netip.AddrPort
+		if err := ap.UnmarshalBinary(s); err != nil {
+			return fmt.Errorf("converting %q to netip.AddrPort: %w", s, err)
+		}
+		*d = ap
+		return nil
+	case netip.AddrPort:
+		*d = s
+		return nil
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, d)
+}
+
+// assignHardwareAddr converts the textual forms accepted by net.ParseMAC and
+// PostgreSQL's macaddr, like "08002b:010203" or "08002b010203", as well as raw
+// 6, 8 or 20 byte addresses into d.
+func assignHardwareAddr(d *net.HardwareAddr, src any) error {
+	switch s := src.(type) {
+	case string:
+		mac, err := parseMAC(s)
+		if err != nil {
+			return err
+		}
+		*d = mac
+		return nil
+	case []byte:
+		if mac, err := parseMAC(string(s)); err == nil {
+			*d = mac
+			return nil
+		}
+		switch len(s) {
+		case 6, 8, 20:
+			*d = net.HardwareAddr(append([]byte(nil), s...))
+			return nil
+		}
+		return fmt.Errorf("converting %q to net.HardwareAddr: invalid MAC address", s)
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, d)
+}
+
+func parseMAC(s string) (net.HardwareAddr, error) {
+	if mac, err := net.ParseMAC(s); err == nil {
+		return mac, nil
+	}
+
+	digits := strings.NewReplacer(":", "", "-", "", ".", "").Replace(s)
+	if len(digits) == 12 || len(digits) == 16 {
+		if mac, err := hex.DecodeString(digits); err == nil {
+			return mac, nil
+		}
+	}
+
+	return nil, fmt.Errorf("converting %q to net.HardwareAddr: invalid MAC address", s)
+}
@@ -0,0 +1,54 @@
+package convert
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestAssignAddrPort(t *testing.T) {
+	expected := netip.MustParseAddrPort("[2001:db8::1]:5432")
+	binary, _ := expected.MarshalBinary()
+
+	for _, src := range []any{"[2001:db8::1]:5432", []byte("[2001:db8::1]:5432"), binary, expected} {
+		var ap netip.AddrPort
+		if err := Assign(&ap, src); err != nil || ap != expected {
+			t.Errorf("Expected %s from %#v, got %s (%v)", expected, src, ap, err)
+		}
+	}
+
+	var ap netip.AddrPort
+	if err := Assign(&ap, "10.0.0.1"); err == nil {
+		t.Errorf("Expected error for address without port, got %s", ap)
+	}
+	if err := Assign(&ap, int64(1)); err == nil {
+		t.Errorf("Expected error for integer source, got %s", ap)
+	}
+}
+
+func TestAssignHardwareAddr(t *testing.T) {
+	expected := "08:00:2b:01:02:03"
+	for _, src := range []any{
+		"08:00:2b:01:02:03",
+		"08-00-2B-01-02-03",
+		"0800.2b01.0203",
+		"08002b:010203",
+		"08002b-010203",
+		"08002b010203",
+		[]byte("08:00:2b:01:02:03"),
+		[]byte{0x08, 0x00, 0x2b, 0x01, 0x02, 0x03},
+	} {
+		var mac net.HardwareAddr
+		if err := Assign(&mac, src); err != nil || mac.String() != expected {
+			t.Errorf("Expected %s from %#v, got %s (%v)", expected, src, mac, err)
+		}
+	}
+
+	var mac net.HardwareAddr
+	if err := Assign(&mac, "not a mac"); err == nil {
+		t.Errorf("Expected error for invalid MAC, got %s", mac)
+	}
+	if err := Assign(&mac, []byte{1, 2, 3}); err == nil {
+		t.Errorf("Expected error for short MAC, got %s", mac)
+	}
+}
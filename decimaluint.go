@@ -0,0 +1,41 @@
+package goption
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+)
+
+// DecimalUint is an Option of an unsigned integer which is written to
+// databases as a decimal string when it doesn't fit in an int64, for columns
+// storing unsigned 64-bit IDs as DECIMAL or VARCHAR. Option.Value rejects
+// such values. Smaller values are written as int64s, and Scan parses the
+// full range back. Use it as the field type of such columns or wrap arguments
+// with WriteDecimalUint.
+type DecimalUint[T ~uint | ~uint64] struct {
+	Option[T]
+}
+
+// WriteDecimalUint returns o wrapped so values above math.MaxInt64 are
+// written as decimal strings.
+func WriteDecimalUint[T ~uint | ~uint64](o Option[T]) DecimalUint[T] {
+	return DecimalUint[T]{Option: o}
+}
+
+// Value implements driver.Valuer
+func (d DecimalUint[T]) Value() (driver.Value, error) {
+	if !d.ok {
+		return nil, nil
+	}
+
+	if u := uint64(d.t); u >= 1<<63 {
+		return strconv.FormatUint(u, 10), nil
+	}
+	return int64(d.t), nil
+}
+
+// ValueContext implements ValuerContext like Value, so the promoted method of
+// the Option doesn't reject large values.
+func (d DecimalUint[T]) ValueContext(ctx context.Context) (driver.Value, error) {
+	return d.Value()
+}
@@ -0,0 +1,50 @@
+package goption
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+)
+
+type unsignedID uint64
+
+func TestDecimalUintRoundTrip(t *testing.T) {
+	for _, u := range []uint64{0, 1<<63 - 1, 1 << 63, math.MaxUint64} {
+		v, err := WriteDecimalUint(Some(u)).Value()
+		if err != nil {
+			t.Fatalf("Failed converting %d: %s", u, err)
+		}
+		if u < 1<<63 && v != int64(u) {
+			t.Errorf("Expected int64 %d, got %#v", u, v)
+		}
+		if u >= 1<<63 && v != strconv.FormatUint(u, 10) {
+			t.Errorf("Expected string %d, got %#v", u, v)
+		}
+
+		var scanned DecimalUint[uint64]
+		if err := scanned.Scan(v); err != nil || scanned.Unwrap() != u {
+			t.Errorf("Expected %d to round trip, got %v (%v)", u, scanned, err)
+		}
+		if err := scanned.Scan([]byte(strconv.FormatUint(u, 10))); err != nil || scanned.Unwrap() != u {
+			t.Errorf("Expected %d to scan from bytes, got %v (%v)", u, scanned, err)
+		}
+	}
+
+	if v, err := WriteDecimalUint(Some(unsignedID(math.MaxUint64))).ValueContext(context.Background()); err != nil || v != "18446744073709551615" {
+		t.Errorf("Expected string for named type, got %#v (%v)", v, err)
+	}
+	if v, err := WriteDecimalUint(None[uint]()).Value(); err != nil || v != nil {
+		t.Errorf("Expected NULL, got %#v (%v)", v, err)
+	}
+	var o Option[uint64]
+	if err := o.Scan("18446744073709551616"); err == nil {
+		t.Errorf("Expected error for overflowing string")
+	}
+}
+
+func TestUint64HighBitRejectedByDefault(t *testing.T) {
+	if _, err := Some(uint64(math.MaxUint64)).Value(); err == nil {
+		t.Errorf("Expected error without DecimalUint")
+	}
+}
@@ -0,0 +1,147 @@
+package goption
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// DefaultsOption configures ApplyDefaults.
+type DefaultsOption func(*defaultsConfig)
+
+type defaultsConfig struct {
+	flags     *flag.FlagSet
+	lookupEnv func(string) (string, bool)
+}
+
+// WithDefaultsFlagSet sets the flags "flag:" sources read, flag.CommandLine
+// by default.
+func WithDefaultsFlagSet(flags *flag.FlagSet) DefaultsOption {
+	return func(c *defaultsConfig) {
+		c.flags = flags
+	}
+}
+
+// WithDefaultsEnv sets how "env:" sources read environment variables,
+// os.LookupEnv by default.
+func WithDefaultsEnv(lookupEnv func(key string) (string, bool)) DefaultsOption {
+	return func(c *defaultsConfig) {
+		c.lookupEnv = lookupEnv
+	}
+}
+
+// ApplyDefaults fills the empty Option fields of dst, a pointer to a struct,
+// from the fallback ladder in their optdefault tags, so it can run after a
+// decoder without overriding what was decoded. A tag lists sources in order
+// of priority, as a Resolver does:
+//
+//	Port Option[int] `optdefault:"flag:port,env:PORT,lit:8080"`
+//
+// "env:KEY" reads a set environment variable, "flag:name" a flag which was
+// set on the command line and "lit:value" is a literal, which takes the rest
+// of the tag so it may contain commas. Values are parsed with UnmarshalText.
+func ApplyDefaults(dst any, opts ...DefaultsOption) error {
+	c := defaultsConfig{flags: flag.CommandLine, lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported defaults destination %T, must be a pointer to a struct", dst)
+	}
+	rv = rv.Elem()
+
+	setFlags := make(map[string]string)
+	c.flags.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = f.Value.String()
+	})
+
+	t := rv.Type()
+	for _, f := range cachedFields(t, "optdefault", false) {
+		sf := t.FieldByIndex(f.index)
+		tag, ok := sf.Tag.Lookup("optdefault")
+		if !ok {
+			continue
+		}
+		if !isOptionType(sf.Type) {
+			return fmt.Errorf("field %s has an optdefault tag but isn't an Option", sf.Name)
+		}
+
+		fv := fieldByIndex(rv, f.index, true)
+		if !fv.IsValid() {
+			return fmt.Errorf("cannot set field %s behind a nil pointer to an unexported struct", sf.Name)
+		}
+		opt, _ := asReflectOption(fv)
+		if _, present := opt.reflectGet(); present {
+			continue
+		}
+
+		r, err := c.defaultsResolver(tag, setFlags)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		v, source := r.Resolve()
+		text, ok := v.Get()
+		if !ok {
+			continue
+		}
+		if err := fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("field %s from %s: %w", sf.Name, source, err)
+		}
+	}
+
+	return nil
+}
+
+// defaultsResolver returns a Resolver of the sources listed in tag.
+func (c *defaultsConfig) defaultsResolver(tag string, setFlags map[string]string) (*Resolver[string], error) {
+	r := NewResolver[string]()
+	priority := 0
+	for tag != "" {
+		source, rest, _ := strings.Cut(tag, ",")
+		kind, arg, ok := strings.Cut(source, ":")
+		if !ok {
+			return nil, fmt.Errorf("optdefault source %q has no kind", source)
+		}
+
+		switch kind {
+		case "env":
+			lookupEnv := c.lookupEnv
+			r.Register(source, priority, func() Option[string] {
+				v, ok := lookupEnv(arg)
+				if !ok {
+					return None[string]()
+				}
+				return Some(v)
+			})
+		case "flag":
+			if c.flags.Lookup(arg) == nil {
+				return nil, fmt.Errorf("optdefault source %q names an undefined flag", source)
+			}
+			r.Register(source, priority, func() Option[string] {
+				return GetMap(setFlags, arg)
+			})
+		case "lit":
+			arg, rest = strings.TrimPrefix(tag, "lit:"), ""
+			r.Register("lit", priority, func() Option[string] {
+				return Some(arg)
+			})
+		default:
+			return nil, fmt.Errorf("unknown optdefault source kind %q", kind)
+		}
+
+		// Sources are listed from the highest priority down.
+		priority--
+		tag = rest
+	}
+
+	if priority == 0 {
+		return nil, errors.New("optdefault tag is empty")
+	}
+	return r, nil
+}
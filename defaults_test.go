@@ -0,0 +1,86 @@
+package goption
+
+import (
+	"flag"
+	"testing"
+)
+
+type serverConfig struct {
+	Host    Option[string] `optdefault:"env:HOST,lit:localhost"`
+	Port    Option[int]    `optdefault:"flag:port,env:PORT,lit:8080"`
+	Debug   Option[bool]   `optdefault:"flag:debug,lit:false"`
+	Tags    Option[string] `optdefault:"lit:a,b"`
+	Timeout Option[int]    `optdefault:"env:TIMEOUT"`
+	Name    Option[string]
+}
+
+func TestApplyDefaults(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int("port", 0, "")
+	flags.Bool("debug", true, "")
+	if err := flags.Parse([]string{"-port", "9090"}); err != nil {
+		t.Fatalf("Failed parsing flags: %s", err)
+	}
+	env := map[string]string{"HOST": "db", "PORT": "7070"}
+	opts := []DefaultsOption{
+		WithDefaultsFlagSet(flags),
+		WithDefaultsEnv(func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}),
+	}
+
+	c := serverConfig{Host: Some("decoded")}
+	if err := ApplyDefaults(&c, opts...); err != nil {
+		t.Fatalf("Failed applying defaults: %s", err)
+	}
+	if c.Host.Unwrap() != "decoded" {
+		t.Errorf("Expected decoded host to be kept, got %v", c.Host)
+	}
+	if c.Port.Unwrap() != 9090 {
+		t.Errorf("Expected port from flag, got %v", c.Port)
+	}
+	if !c.Debug.Ok() || c.Debug.Unwrap() {
+		t.Errorf("Expected unset flag to fall back to literal false, got %v", c.Debug)
+	}
+	if c.Tags.Unwrap() != "a,b" {
+		t.Errorf("Expected literal with comma, got %v", c.Tags)
+	}
+	if c.Timeout.Ok() || c.Name.Ok() {
+		t.Errorf("Expected fields without a value to stay empty, got %v and %v", c.Timeout, c.Name)
+	}
+
+	c = serverConfig{}
+	if err := ApplyDefaults(&c, WithDefaultsFlagSet(flag.NewFlagSet("empty", flag.ContinueOnError))); err == nil {
+		t.Errorf("Expected error for undefined flag")
+	}
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	env := WithDefaultsEnv(func(string) (string, bool) { return "x", true })
+	flags := WithDefaultsFlagSet(flag.NewFlagSet("test", flag.ContinueOnError))
+
+	var port struct {
+		Port Option[int] `optdefault:"env:PORT"`
+	}
+	if err := ApplyDefaults(&port, env, flags); err == nil {
+		t.Errorf("Expected parse error")
+	}
+
+	var plain struct {
+		Port int `optdefault:"lit:1"`
+	}
+	if err := ApplyDefaults(&plain, flags); err == nil {
+		t.Errorf("Expected error for non-option field")
+	}
+
+	var unknown struct {
+		Port Option[int] `optdefault:"file:port"`
+	}
+	if err := ApplyDefaults(&unknown, flags); err == nil {
+		t.Errorf("Expected error for unknown source kind")
+	}
+	if err := ApplyDefaults(port, flags); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
@@ -0,0 +1,128 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Diff fills patchOut, a pointer to a struct of Option or Undefinable fields,
+// with the fields which differ between old and new, structs or pointers to
+// structs, for audit logs and minimal updates. It's the inverse of
+// ApplyPatch: applying patchOut to old yields new for the fields of
+// patchOut. Fields are matched by name like ApplyPatch matches them, and
+// fields of old and new may be values, Options of them or pointers to them.
+//
+// Unchanged fields of patchOut are emptied. Fields which changed to an empty
+// Option or nil pointer are defined as empty if they're Undefinable, and are
+// an error if they're Options, which can't express clearing a field.
+func Diff(old, new any, patchOut any) error {
+	ov := reflect.Indirect(reflect.ValueOf(old))
+	nv := reflect.Indirect(reflect.ValueOf(new))
+	if ov.Kind() != reflect.Struct || nv.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported diff of %T and %T, must be structs", old, new)
+	}
+	pv := reflect.ValueOf(patchOut)
+	if pv.Kind() != reflect.Pointer || pv.IsNil() || pv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported patch %T, must be a pointer to a struct", patchOut)
+	}
+	ov, nv, pv = addressable(ov), addressable(nv), pv.Elem()
+
+	oldFields := fieldsByName(ov.Type())
+	newFields := fieldsByName(nv.Type())
+	for _, f := range cachedFields(pv.Type(), "patch", false) {
+		of, inOld := oldFields[f.name]
+		nf, inNew := newFields[f.name]
+		if !inOld || !inNew {
+			return fmt.Errorf("field %s of the patch isn't in both %s and %s", f.name, ov.Type(), nv.Type())
+		}
+
+		target := fieldByIndex(pv, f.index, true)
+		if !target.IsValid() {
+			return fmt.Errorf("cannot set field %s behind a nil pointer to an unexported struct", f.name)
+		}
+		opt, isOption := asReflectOption(target)
+		u, isUndefinable := target.Addr().Interface().(patchTarget)
+		if !isOption && !isUndefinable {
+			return fmt.Errorf("field %s of the patch must be an Option or Undefinable", f.name)
+		}
+
+		d := diffFields(fieldByIndex(ov, of.index, false), fieldByIndex(nv, nf.index, false))
+
+		var err error
+		switch {
+		case isUndefinable && !d.changed():
+			u.patchUnset()
+		case isUndefinable:
+			err = u.patchSet(d.newVal, d.newPresent)
+		case !d.changed():
+			opt.reflectClear()
+		case !d.newPresent:
+			err = fmt.Errorf("cleared, which an Option can't express")
+		default:
+			err = setPatched(target, d.newVal, true)
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldDiff holds the values of a field of the old and new structs of Diff.
+type fieldDiff struct {
+	oldVal, newVal         reflect.Value
+	oldPresent, newPresent bool
+}
+
+// diffFields compares the fields of, of the old struct, and nf, of the new
+// one.
+func diffFields(of, nf reflect.Value) fieldDiff {
+	var d fieldDiff
+	d.oldVal, d.oldPresent = diffValue(of)
+	d.newVal, d.newPresent = diffValue(nf)
+	return d
+}
+
+// changed reports whether the field was set, cleared or changed its value.
+func (d fieldDiff) changed() bool {
+	return d.oldPresent != d.newPresent || (d.newPresent && !reflect.DeepEqual(d.oldVal.Interface(), d.newVal.Interface()))
+}
+
+// diffValue returns the value of the field fv and whether it's present,
+// looking through Options and pointers.
+func diffValue(fv reflect.Value) (reflect.Value, bool) {
+	if !fv.IsValid() {
+		return reflect.Value{}, false
+	}
+	if opt, isOption := asReflectOption(addressable(fv)); isOption {
+		return opt.reflectGet()
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		return fv.Elem(), true
+	}
+
+	return fv, true
+}
+
+// patchTarget is implemented by *Undefinable.
+type patchTarget interface {
+	patchSet(val reflect.Value, present bool) error
+	patchUnset()
+}
+
+func (u *Undefinable[T]) patchSet(val reflect.Value, present bool) error {
+	u.set = true
+	if !present {
+		u.opt = None[T]()
+		return nil
+	}
+	return setPatched(reflect.ValueOf(&u.opt).Elem(), val, true)
+}
+
+func (u *Undefinable[T]) patchUnset() {
+	*u = Undefined[T]()
+}
@@ -0,0 +1,71 @@
+package goption
+
+import (
+	"testing"
+)
+
+type userDiff struct {
+	Name     Option[string]
+	Age      Option[int]
+	Email    Undefinable[string]
+	Nickname Undefinable[string]
+	About    Option[string] `patch:"Bio"`
+}
+
+func TestDiff(t *testing.T) {
+	nickname := "countess"
+	old := patchedUser{Name: "ada", Age: 36, Email: Some("ada@example.com"), Nickname: &nickname, Bio: "mathematician"}
+	changed := old
+	changed.Age = 37
+	changed.Email = None[string]()
+	changed.Bio = "poet"
+
+	patch := userDiff{Name: Some("stale")}
+	if err := Diff(old, &changed, &patch); err != nil {
+		t.Fatalf("Failed diffing: %s", err)
+	}
+	if patch.Name.Ok() || patch.Age.Unwrap() != 37 || !patch.Email.IsSet() || patch.Email.Ok() || patch.Nickname.IsSet() || patch.About.Unwrap() != "poet" {
+		t.Errorf("Unexpected patch: %+v", patch)
+	}
+
+	if err := ApplyPatch(&old, struct {
+		Age   Option[int]
+		Email Undefinable[string]
+		Bio   Option[string]
+	}{patch.Age, patch.Email, patch.About}); err != nil {
+		t.Fatalf("Failed applying diff: %s", err)
+	}
+	if old.Age != changed.Age || old.Email.Ok() || old.Bio != changed.Bio {
+		t.Errorf("Expected applying the diff to yield the new struct, got %+v", old)
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	old := patchedUser{Email: Some("a@b.c")}
+	var patch struct {
+		Email Option[string]
+	}
+	if err := Diff(old, patchedUser{}, &patch); err == nil {
+		t.Errorf("Expected error for clearing through an Option")
+	}
+
+	var plain struct {
+		Name string
+	}
+	if err := Diff(old, old, &plain); err == nil {
+		t.Errorf("Expected error for plain patch field")
+	}
+
+	var unknown struct {
+		Phone Option[string]
+	}
+	if err := Diff(old, old, &unknown); err == nil {
+		t.Errorf("Expected error for unknown field")
+	}
+	if err := Diff(old, 1, &patch); err == nil {
+		t.Errorf("Expected error for non-struct")
+	}
+	if err := Diff(old, old, patch); err == nil {
+		t.Errorf("Expected error for non-pointer patch")
+	}
+}
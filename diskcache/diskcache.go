@@ -0,0 +1,135 @@
+//go:build goption_diskcache
+
+package diskcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Cache stores optional values of T in a directory. T must be supported by
+// goption.EncodeCompact as a field type. Empty options are cached too, so a
+// lookup which found nothing isn't repeated until it expires.
+type Cache[T any] struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// entry is the on-disk form of a cached value.
+type entry[T any] struct {
+	Expires int64
+	Value   goption.Option[T]
+}
+
+// Open returns a Cache storing values in dir, which is created if it doesn't
+// exist. Values expire ttl after they're set.
+func Open[T any](dir string, ttl time.Duration) (*Cache[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("diskcache: %w", err)
+	}
+
+	return &Cache[T]{dir: dir, ttl: ttl, now: time.Now}, nil
+}
+
+// path returns the file holding key.
+func (c *Cache[T]) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Lookup returns the value cached for key, and whether it was found and not
+// expired. Expired entries are removed.
+func (c *Cache[T]) Lookup(key string) (goption.Option[T], bool, error) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return goption.None[T](), false, nil
+	}
+	if err != nil {
+		return goption.None[T](), false, fmt.Errorf("diskcache: %w", err)
+	}
+
+	var e entry[T]
+	if err := goption.DecodeCompact(data, &e); err != nil {
+		return goption.None[T](), false, fmt.Errorf("diskcache: decoding %q: %w", key, err)
+	}
+	if c.now().UnixNano() >= e.Expires {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return goption.None[T](), false, fmt.Errorf("diskcache: %w", err)
+		}
+		return goption.None[T](), false, nil
+	}
+
+	return e.Value, true, nil
+}
+
+// Get returns the value cached for key. Missing and expired keys are
+// returned as empty options, use Lookup to tell them apart from cached empty
+// options.
+func (c *Cache[T]) Get(key string) (goption.Option[T], error) {
+	o, _, err := c.Lookup(key)
+	return o, err
+}
+
+// Set caches o for key. The file is replaced atomically, so concurrent
+// readers see either the old or the new value.
+func (c *Cache[T]) Set(key string, o goption.Option[T]) error {
+	data, err := goption.EncodeCompact(entry[T]{
+		Expires: c.now().Add(c.ttl).UnixNano(),
+		Value:   o,
+	})
+	if err != nil {
+		return fmt.Errorf("diskcache: encoding %q: %w", key, err)
+	}
+
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("diskcache: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("diskcache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("diskcache: %w", err)
+	}
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("diskcache: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes key from the cache.
+func (c *Cache[T]) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("diskcache: %w", err)
+	}
+
+	return nil
+}
+
+// Memoize returns the value cached for key, or calls fetch and caches its
+// result if there's none. Errors of fetch aren't cached.
+func (c *Cache[T]) Memoize(key string, fetch func() (goption.Option[T], error)) (goption.Option[T], error) {
+	if o, hit, err := c.Lookup(key); err != nil || hit {
+		return o, err
+	}
+
+	o, err := fetch()
+	if err != nil {
+		return goption.None[T](), err
+	}
+	return o, c.Set(key, o)
+}
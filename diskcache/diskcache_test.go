@@ -0,0 +1,110 @@
+//go:build goption_diskcache
+
+package diskcache
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+type profile struct {
+	Name  string
+	Email goption.Option[string]
+}
+
+func TestCache(t *testing.T) {
+	c, err := Open[profile](t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed opening cache: %s", err)
+	}
+
+	if o, hit, err := c.Lookup("ada"); err != nil || hit || o.Ok() {
+		t.Errorf("Expected miss, got %v %v (%v)", o, hit, err)
+	}
+
+	ada := profile{Name: "ada", Email: goption.Some("ada@example.com")}
+	if err := c.Set("ada", goption.Some(ada)); err != nil {
+		t.Fatalf("Failed setting value: %s", err)
+	}
+	if o, err := c.Get("ada"); err != nil || o.Unwrap() != ada {
+		t.Errorf("Expected %v, got %v (%v)", ada, o, err)
+	}
+
+	if err := c.Set("nobody", goption.None[profile]()); err != nil {
+		t.Fatalf("Failed setting empty value: %s", err)
+	}
+	if o, hit, err := c.Lookup("nobody"); err != nil || !hit || o.Ok() {
+		t.Errorf("Expected cached empty option, got %v %v (%v)", o, hit, err)
+	}
+
+	if err := c.Delete("ada"); err != nil {
+		t.Fatalf("Failed deleting value: %s", err)
+	}
+	if _, hit, _ := c.Lookup("ada"); hit {
+		t.Errorf("Expected miss after delete")
+	}
+	if err := c.Delete("ada"); err != nil {
+		t.Errorf("Expected deleting a missing key to succeed, got %s", err)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	c, err := Open[int](dir, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed opening cache: %s", err)
+	}
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+
+	if err := c.Set("n", goption.Some(1)); err != nil {
+		t.Fatalf("Failed setting value: %s", err)
+	}
+	now = now.Add(59 * time.Second)
+	if o, _ := c.Get("n"); o.UnwrapOrDefault() != 1 {
+		t.Errorf("Expected 1 before expiry, got %v", o)
+	}
+
+	now = now.Add(time.Second)
+	if o, hit, err := c.Lookup("n"); err != nil || hit || o.Ok() {
+		t.Errorf("Expected miss after expiry, got %v %v (%v)", o, hit, err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected expired entry to be removed, got %d files", len(files))
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	c, err := Open[string](t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed opening cache: %s", err)
+	}
+
+	calls := 0
+	fetch := func() (goption.Option[string], error) {
+		calls++
+		return goption.None[string](), nil
+	}
+	for i := 0; i < 2; i++ {
+		if o, err := c.Memoize("k", fetch); err != nil || o.Ok() {
+			t.Errorf("Expected empty option, got %v (%v)", o, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single fetch, got %d", calls)
+	}
+
+	errFetch := errors.New("unavailable")
+	if _, err := c.Memoize("e", func() (goption.Option[string], error) {
+		return goption.None[string](), errFetch
+	}); !errors.Is(err, errFetch) {
+		t.Errorf("Expected fetch error, got %v", err)
+	}
+	if _, hit, _ := c.Lookup("e"); hit {
+		t.Errorf("Expected failed fetch not to be cached")
+	}
+}
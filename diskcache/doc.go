@@ -0,0 +1,7 @@
+// Package diskcache is a small on-disk cache of goption.Option values with
+// expiry, for command line tools which memoize optional remote lookups
+// between runs. Values are stored one file per key in goption's compact
+// binary form.
+//
+// The cache is only built with the goption_diskcache build tag.
+package diskcache
@@ -0,0 +1,69 @@
+package goption
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// Integer is a constraint permitting any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// ErrOutOfRange is returned by DowncastInt when a value doesn't fit into the
+// target type.
+var ErrOutOfRange = errors.New("value out of range")
+
+// integerRange returns the bounds of To.
+func integerRange[To Integer]() (min int64, max uint64) {
+	var zero To
+	bits := unsafe.Sizeof(zero) * 8
+	if ^zero < 0 {
+		return -1 << (bits - 1), 1<<(bits-1) - 1
+	}
+	return 0, uint64(^zero)
+}
+
+// clampInt converts v to To, clamping it to the range of To. It reports
+// whether v was in range.
+func clampInt[To, From Integer](v From) (To, bool) {
+	min, max := integerRange[To]()
+	if v < 0 {
+		if int64(v) < min {
+			return To(min), false
+		}
+		return To(v), true
+	}
+
+	if uint64(v) > max {
+		return To(max), false
+	}
+	return To(v), true
+}
+
+// DowncastInt converts the value of o to To, failing with ErrOutOfRange if it
+// doesn't fit. Empty options stay empty.
+func DowncastInt[To, From Integer](o Option[From]) (Option[To], error) {
+	if !o.ok {
+		return None[To](), nil
+	}
+
+	to, ok := clampInt[To](o.t)
+	if !ok {
+		return None[To](), fmt.Errorf("converting %d to %T: %w", o.t, to, ErrOutOfRange)
+	}
+	return Some(to), nil
+}
+
+// SaturateInt converts the value of o to To, clamping it to the smallest or
+// largest value of To if it doesn't fit. Empty options stay empty.
+func SaturateInt[To, From Integer](o Option[From]) Option[To] {
+	if !o.ok {
+		return None[To]()
+	}
+
+	to, _ := clampInt[To](o.t)
+	return Some(to)
+}
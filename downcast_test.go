@@ -0,0 +1,60 @@
+package goption
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+type smallID int16
+
+func TestDowncastInt(t *testing.T) {
+	if o, err := DowncastInt[int8](Some(int64(-128))); err != nil || o.Unwrap() != -128 {
+		t.Errorf("Expected -128, got %v (%v)", o, err)
+	}
+	if o, err := DowncastInt[smallID](Some(int64(300))); err != nil || o.Unwrap() != 300 {
+		t.Errorf("Expected 300, got %v (%v)", o, err)
+	}
+	if o, err := DowncastInt[uint32](Some(int64(math.MaxUint32))); err != nil || o.Unwrap() != math.MaxUint32 {
+		t.Errorf("Expected max uint32, got %v (%v)", o, err)
+	}
+	if o, err := DowncastInt[int64](Some(uint64(math.MaxInt64))); err != nil || o.Unwrap() != math.MaxInt64 {
+		t.Errorf("Expected max int64, got %v (%v)", o, err)
+	}
+	if o, err := DowncastInt[int32](None[int64]()); err != nil || o.Ok() {
+		t.Errorf("Expected empty option, got %v (%v)", o, err)
+	}
+
+	for _, tc := range []func() error{
+		func() error { _, err := DowncastInt[int8](Some(int64(128))); return err },
+		func() error { _, err := DowncastInt[int8](Some(int64(-129))); return err },
+		func() error { _, err := DowncastInt[uint8](Some(-1)); return err },
+		func() error { _, err := DowncastInt[int64](Some(uint64(math.MaxInt64 + 1))); return err },
+		func() error { _, err := DowncastInt[uint32](Some(int64(math.MaxUint32 + 1))); return err },
+	} {
+		if err := tc(); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Expected ErrOutOfRange, got %v", err)
+		}
+	}
+}
+
+func TestSaturateInt(t *testing.T) {
+	if o := SaturateInt[int8](Some(int64(1000))); o.Unwrap() != math.MaxInt8 {
+		t.Errorf("Expected max int8, got %v", o)
+	}
+	if o := SaturateInt[int8](Some(int64(-1000))); o.Unwrap() != math.MinInt8 {
+		t.Errorf("Expected min int8, got %v", o)
+	}
+	if o := SaturateInt[uint16](Some(-5)); o.Unwrap() != 0 {
+		t.Errorf("Expected 0, got %v", o)
+	}
+	if o := SaturateInt[int64](Some(uint64(math.MaxUint64))); o.Unwrap() != math.MaxInt64 {
+		t.Errorf("Expected max int64, got %v", o)
+	}
+	if o := SaturateInt[smallID](Some(int64(12))); o.Unwrap() != 12 {
+		t.Errorf("Expected 12, got %v", o)
+	}
+	if o := SaturateInt[int8](None[int]()); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+}
@@ -0,0 +1,174 @@
+package goption
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DSN builds driver connection strings from optional parameters. Only
+// present parameters are emitted, parameter names follow libpq.
+type DSN struct {
+	Host           Option[string]
+	Port           Option[uint16]
+	User           Option[string]
+	Password       Option[string]
+	DBName         Option[string]
+	SSLMode        Option[string]
+	SSLCert        Option[string]
+	SSLKey         Option[string]
+	SSLRootCert    Option[string]
+	ConnectTimeout Option[time.Duration]
+	// Params holds additional driver specific parameters. Keys of the
+	// built-in parameters are rejected by Validate and ignored otherwise.
+	Params map[string]Option[string]
+}
+
+// dsnKeys are the keys of the built-in parameters of DSN.
+var dsnKeys = map[string]bool{
+	"host": true, "port": true, "user": true, "password": true, "dbname": true,
+	"sslmode": true, "sslcert": true, "sslkey": true, "sslrootcert": true,
+	"connect_timeout": true,
+}
+
+// Validate returns an error if a key of Params is the key of a built-in
+// parameter, which is set by its field instead.
+func (d DSN) Validate() error {
+	keys := make([]string, 0, len(d.Params))
+	for key := range d.Params {
+		if dsnKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		return fmt.Errorf("DSN parameters %s must be set by their fields", strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
+// Set sets the driver specific parameter key to value.
+func (d *DSN) Set(key string, value Option[string]) *DSN {
+	if d.Params == nil {
+		d.Params = make(map[string]Option[string])
+	}
+
+	d.Params[key] = value
+	return d
+}
+
+// dsnParam is a present DSN parameter.
+type dsnParam struct {
+	key, value string
+}
+
+// params returns every present parameter except the ones which are part of a
+// URL's authority and path when forURL is set.
+func (d DSN) params(forURL bool) []dsnParam {
+	var params []dsnParam
+	add := func(key string, value Option[string]) {
+		if value.ok {
+			params = append(params, dsnParam{key: key, value: value.t})
+		}
+	}
+
+	if !forURL {
+		add("host", d.Host)
+		add("port", Apply(d.Port, func(p uint16) string { return strconv.Itoa(int(p)) }))
+		add("user", d.User)
+		add("password", d.Password)
+		add("dbname", d.DBName)
+	}
+	add("sslmode", d.SSLMode)
+	add("sslcert", d.SSLCert)
+	add("sslkey", d.SSLKey)
+	add("sslrootcert", d.SSLRootCert)
+	add("connect_timeout", Apply(d.ConnectTimeout, func(t time.Duration) string {
+		seconds := (t + time.Second - 1) / time.Second
+		return strconv.FormatInt(int64(seconds), 10)
+	}))
+
+	keys := make([]string, 0, len(d.Params))
+	for key := range d.Params {
+		if !dsnKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, d.Params[key])
+	}
+
+	return params
+}
+
+// String returns the DSN in keyword/value format, e.g.
+// "host=localhost port=5432 dbname=app".
+func (d DSN) String() string {
+	var sb strings.Builder
+	for i, param := range d.params(false) {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(param.key)
+		sb.WriteByte('=')
+		sb.WriteString(quoteDSNValue(param.value))
+	}
+
+	return sb.String()
+}
+
+// URL returns the DSN as a URL with the given scheme, e.g.
+// "postgres://user@localhost:5432/app?sslmode=disable". A password without
+// a user is emitted with an empty user name.
+func (d DSN) URL(scheme string) *url.URL {
+	u := &url.URL{Scheme: scheme}
+
+	if password, ok := d.Password.Get(); ok {
+		u.User = url.UserPassword(d.User.UnwrapOrDefault(), password)
+	} else if user, ok := d.User.Get(); ok {
+		u.User = url.User(user)
+	}
+
+	host := d.Host.UnwrapOrDefault()
+	if port, ok := d.Port.Get(); ok {
+		host = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	u.Host = host
+
+	if dbname, ok := d.DBName.Get(); ok {
+		u.Path = "/" + dbname
+	}
+
+	query := url.Values{}
+	for _, param := range d.params(true) {
+		query.Set(param.key, param.value)
+	}
+	u.RawQuery = query.Encode()
+
+	return u
+}
+
+// quoteDSNValue quotes value for the keyword/value format if needed.
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\\'") {
+		return value
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for _, r := range value {
+		if r == '\\' || r == '\'' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('\'')
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package goption
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDSNString(t *testing.T) {
+	var dsn DSN
+	if str := dsn.String(); str != "" {
+		t.Errorf("Expected empty DSN, got %q", str)
+	}
+
+	dsn = DSN{
+		Host:           Some("localhost"),
+		Port:           Some[uint16](5432),
+		User:           Some("test"),
+		Password:       Some("it's secret"),
+		SSLMode:        Some("disable"),
+		ConnectTimeout: Some(1500 * time.Millisecond),
+	}
+	dsn.Set("application_name", Some("goption")).Set("options", None[string]())
+
+	expected := `host=localhost port=5432 user=test password='it\'s secret' sslmode=disable connect_timeout=2 application_name=goption`
+	if str := dsn.String(); str != expected {
+		t.Errorf("Unexpected DSN:\n%s\nexpected:\n%s", str, expected)
+	}
+}
+
+func TestDSNURL(t *testing.T) {
+	dsn := DSN{
+		Host:    Some("localhost"),
+		Port:    Some[uint16](5432),
+		User:    Some("test"),
+		DBName:  Some("app"),
+		SSLMode: Some("disable"),
+	}
+
+	if u := dsn.URL("postgres").String(); u != "postgres://test@localhost:5432/app?sslmode=disable" {
+		t.Errorf("Unexpected URL: %s", u)
+	}
+
+	dsn = DSN{Host: Some("db"), Password: Some("secret")}
+	if u := dsn.URL("mysql").String(); u != "mysql://:secret@db" {
+		t.Errorf("Unexpected URL: %s", u)
+	}
+}
+
+func TestDSNValidate(t *testing.T) {
+	dsn := DSN{Host: Some("localhost"), SSLMode: Some("disable")}
+	dsn.Set("application_name", Some("goption"))
+	if err := dsn.Validate(); err != nil {
+		t.Errorf("Expected valid DSN, got %v", err)
+	}
+
+	dsn.Set("sslmode", Some("require")).Set("host", Some("other"))
+	if err := dsn.Validate(); err == nil || !strings.Contains(err.Error(), "host, sslmode") {
+		t.Errorf("Expected colliding parameters to be rejected, got %v", err)
+	}
+	if str := dsn.String(); str != "host=localhost sslmode=disable application_name=goption" {
+		t.Errorf("Expected colliding parameters to be ignored, got %q", str)
+	}
+	if u := dsn.URL("postgres").String(); u != "postgres://localhost?application_name=goption&sslmode=disable" {
+		t.Errorf("Expected colliding parameters to be ignored, got %s", u)
+	}
+}
@@ -0,0 +1,78 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsOptionType reports whether t is an Option[T] for some T. Together with
+// OptionElem, NewSome, NewNone and Inspect it lets code which only knows the
+// type of an Option at runtime, like routers and serializers, work with it
+// without depending on its layout.
+func IsOptionType(t reflect.Type) bool {
+	return t != nil && isOptionType(t)
+}
+
+// OptionElem returns T if t is an Option[T].
+func OptionElem(t reflect.Type) (reflect.Type, bool) {
+	if !IsOptionType(t) {
+		return nil, false
+	}
+
+	return reflect.New(t).Interface().(reflectOption).reflectType(), true
+}
+
+// NewSome returns an Option of type t, an Option[T], holding v, which must be
+// assignable to T. The result can be asserted to Option[T].
+func NewSome(t reflect.Type, v any) (any, error) {
+	elem, ok := OptionElem(t)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %v, must be an Option", t)
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		switch elem.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+			rv = reflect.Zero(elem)
+		default:
+			return nil, fmt.Errorf("cannot use nil as %s", elem)
+		}
+	}
+	if !rv.Type().AssignableTo(elem) {
+		return nil, fmt.Errorf("cannot use %s as %s", rv.Type(), elem)
+	}
+
+	o := reflect.New(t)
+	o.Interface().(reflectOption).reflectSet().Set(rv)
+	return o.Elem().Interface(), nil
+}
+
+// NewNone returns an empty Option of type t, an Option[T].
+func NewNone(t reflect.Type) (any, error) {
+	if !IsOptionType(t) {
+		return nil, fmt.Errorf("unsupported type %v, must be an Option", t)
+	}
+
+	return reflect.Zero(t).Interface(), nil
+}
+
+// Inspect returns the value of o, an Option or a non-nil pointer to one, and
+// whether it's present. Anything else is reported as not present; use
+// IsOptionType to tell it apart from an empty Option.
+func Inspect(o any) (any, bool) {
+	rv := reflect.ValueOf(o)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || !isOptionType(rv.Type()) {
+		return nil, false
+	}
+
+	opt, _ := asReflectOption(addressable(rv))
+	val, ok := opt.reflectGet()
+	if !ok {
+		return nil, false
+	}
+	return val.Interface(), true
+}
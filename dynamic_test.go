@@ -0,0 +1,64 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsOptionType(t *testing.T) {
+	if !IsOptionType(reflect.TypeOf(Some(1))) {
+		t.Errorf("Expected Option[int] to be an option type")
+	}
+	if IsOptionType(reflect.TypeOf(1)) || IsOptionType(reflect.TypeOf(&Option[int]{})) || IsOptionType(nil) {
+		t.Errorf("Expected other types not to be option types")
+	}
+
+	if elem, ok := OptionElem(reflect.TypeOf(None[[]string]())); !ok || elem != reflect.TypeOf([]string(nil)) {
+		t.Errorf("Expected []string, got %v", elem)
+	}
+}
+
+func TestNewSome(t *testing.T) {
+	optType := reflect.TypeOf(Option[int64]{})
+	o, err := NewSome(optType, int64(3))
+	if err != nil || o.(Option[int64]).Unwrap() != 3 {
+		t.Errorf("Expected Some(3), got %v (%v)", o, err)
+	}
+	if _, err := NewSome(optType, 3); err == nil {
+		t.Errorf("Expected error for value of another type")
+	}
+	if _, err := NewSome(optType, nil); err == nil {
+		t.Errorf("Expected error for nil value of non-nilable type")
+	}
+	if _, err := NewSome(reflect.TypeOf(1), 1); err == nil {
+		t.Errorf("Expected error for non-option type")
+	}
+
+	o, err = NewSome(reflect.TypeOf(Option[any]{}), nil)
+	if err != nil || !o.(Option[any]).Ok() {
+		t.Errorf("Expected Some(nil), got %v (%v)", o, err)
+	}
+
+	o, err = NewNone(optType)
+	if err != nil || o.(Option[int64]).Ok() {
+		t.Errorf("Expected None, got %v (%v)", o, err)
+	}
+	if _, err := NewNone(reflect.TypeOf("")); err == nil {
+		t.Errorf("Expected error for non-option type")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	if v, ok := Inspect(Some("a")); !ok || v != "a" {
+		t.Errorf("Expected a, got %v", v)
+	}
+	o := Some(2)
+	if v, ok := Inspect(&o); !ok || v != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	for _, v := range []any{None[int](), nil, 1, (*Option[int])(nil)} {
+		if got, ok := Inspect(v); ok {
+			t.Errorf("Expected %#v not to be present, got %v", v, got)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package env reads environment variables into goption.Options, so unset
+// variables are empty rather than zero values.
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Lookup returns the value of the environment variable key parsed as a T,
+// or an empty option if it's unset or can't be parsed. Use Parse to tell
+// the two apart.
+func Lookup[T any](key string) goption.Option[T] {
+	o, _ := Parse[T](key)
+	return o
+}
+
+// Parse returns the value of the environment variable key parsed as a T, or
+// an empty option if it's unset. Durations are parsed with
+// time.ParseDuration, other types with goption.Option's UnmarshalText, which
+// handles strings, booleans, numbers and encoding.TextUnmarshaler types like
+// time.Time.
+func Parse[T any](key string) (goption.Option[T], error) {
+	var o goption.Option[T]
+	err := parseInto(reflect.ValueOf(&o).Elem(), key)
+	return o, err
+}
+
+var durationOptionType = reflect.TypeOf(goption.Option[time.Duration]{})
+
+// parseInto sets fv, an Option, to the parsed value of the environment
+// variable key if it's set.
+func parseInto(fv reflect.Value, key string) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+
+	if fv.Type() == durationOptionType {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("parsing environment variable %s: %w", key, err)
+		}
+		fv.Set(reflect.ValueOf(goption.Some(d)))
+		return nil
+	}
+
+	if err := fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v)); err != nil {
+		return fmt.Errorf("parsing environment variable %s: %w", key, err)
+	}
+	return nil
+}
+
+// Load fills the Option fields of dst, a pointer to a struct, which have an
+// env tag naming an environment variable, like Parse. Fields of unset
+// variables are left unchanged. Fields of embedded structs are loaded too.
+func Load(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported env destination %T, must be a pointer to a struct", dst)
+	}
+
+	return load(rv.Elem())
+}
+
+func load(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := load(rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, ok := f.Tag.Lookup("env")
+		if !ok || key == "-" {
+			continue
+		}
+		if !f.IsExported() || !goption.IsOptionType(f.Type) {
+			return fmt.Errorf("field %s has an env tag but isn't an exported Option", f.Name)
+		}
+		if err := parseInto(rv.Field(i), key); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+func TestLookup(t *testing.T) {
+	t.Setenv("GOPTION_PORT", "8080")
+	t.Setenv("GOPTION_EMPTY", "")
+	t.Setenv("GOPTION_BAD", "x")
+
+	if o := Lookup[int]("GOPTION_PORT"); o.Unwrap() != 8080 {
+		t.Errorf("Expected 8080, got %v", o)
+	}
+	if o := Lookup[string]("GOPTION_EMPTY"); !o.Ok() || o.Unwrap() != "" {
+		t.Errorf("Expected Some(\"\"), got %v", o)
+	}
+	if o := Lookup[int]("GOPTION_UNSET"); o.Ok() {
+		t.Errorf("Expected unset variable to be empty, got %v", o)
+	}
+	if o := Lookup[int]("GOPTION_BAD"); o.Ok() {
+		t.Errorf("Expected invalid variable to be empty, got %v", o)
+	}
+	if _, err := Parse[int]("GOPTION_BAD"); err == nil {
+		t.Errorf("Expected parse error")
+	}
+}
+
+type baseConfig struct {
+	Debug goption.Option[bool] `env:"GOPTION_DEBUG"`
+}
+
+type config struct {
+	baseConfig
+	Timeout goption.Option[time.Duration] `env:"GOPTION_TIMEOUT"`
+	Since   goption.Option[time.Time]     `env:"GOPTION_SINCE"`
+	Workers goption.Option[int]           `env:"GOPTION_WORKERS"`
+	Name    goption.Option[string]
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("GOPTION_DEBUG", "true")
+	t.Setenv("GOPTION_TIMEOUT", "1m30s")
+	t.Setenv("GOPTION_SINCE", "2024-01-02T03:04:05Z")
+
+	c := config{Workers: goption.Some(4)}
+	if err := Load(&c); err != nil {
+		t.Fatalf("Failed loading: %s", err)
+	}
+	if !c.Debug.Unwrap() || c.Timeout.Unwrap() != 90*time.Second || c.Since.Unwrap().Year() != 2024 {
+		t.Errorf("Unexpected config: %+v", c)
+	}
+	if c.Workers.Unwrap() != 4 || c.Name.Ok() {
+		t.Errorf("Expected fields of unset variables to be unchanged, got %+v", c)
+	}
+
+	t.Setenv("GOPTION_TIMEOUT", "soon")
+	if err := Load(&c); err == nil {
+		t.Errorf("Expected error for invalid duration")
+	}
+
+	var plain struct {
+		Port int `env:"GOPTION_PORT"`
+	}
+	if err := Load(&plain); err == nil {
+		t.Errorf("Expected error for non-option field")
+	}
+	if err := Load(c); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
@@ -0,0 +1,133 @@
+// Package factory builds test fixtures of structs with goption.Option fields.
+package factory
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Factory builds values of the struct type T.
+type Factory[T any] struct {
+	values map[string]any
+	rand   *rand.Rand
+}
+
+// NewFactory returns a Factory for T, which must be a struct type. Option
+// fields which aren't set are left empty unless Random is used.
+func NewFactory[T any]() *Factory[T] {
+	return &Factory[T]{values: make(map[string]any)}
+}
+
+// Set sets the field named field to value, which must be assignable to it.
+func (f *Factory[T]) Set(field string, value any) *Factory[T] {
+	f.values[field] = value
+	return f
+}
+
+// Random fills Option fields which aren't set with random values generated
+// from seed, so fixtures are reproducible. Only Options of booleans, numbers,
+// strings, byte slices and time.Time are filled, others are left empty.
+func (f *Factory[T]) Random(seed int64) *Factory[T] {
+	f.rand = rand.New(rand.NewSource(seed))
+	return f
+}
+
+// Build returns a new T. It panics if a field which was set doesn't exist or
+// can't hold its value, since that's a bug in the fixture.
+func (f *Factory[T]) Build() T {
+	var t T
+	rv := reflect.ValueOf(&t).Elem()
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("factory: %s is not a struct", rv.Type()))
+	}
+
+	for name, value := range f.values {
+		field := rv.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			panic(fmt.Sprintf("factory: %s has no settable field %s", rv.Type(), name))
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			field.SetZero()
+			continue
+		}
+		if !v.Type().AssignableTo(field.Type()) {
+			panic(fmt.Sprintf("factory: cannot use %s as %s for field %s", v.Type(), field.Type(), name))
+		}
+		field.Set(v)
+	}
+
+	if f.rand != nil {
+		f.fillRandom(rv)
+	}
+
+	return t
+}
+
+func (f *Factory[T]) fillRandom(rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if _, isSet := f.values[sf.Name]; isSet || !sf.IsExported() || !goption.IsOptionType(sf.Type) {
+			continue
+		}
+
+		elem, _ := goption.OptionElem(sf.Type)
+		src, ok := f.randomValue(elem)
+		if !ok {
+			continue
+		}
+		scanner := rv.Field(i).Addr().Interface().(sql.Scanner)
+		if err := scanner.Scan(src); err != nil {
+			panic(fmt.Sprintf("factory: filling field %s: %s", sf.Name, err))
+		}
+	}
+}
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// randomValue returns a random value which scans into t.
+func (f *Factory[T]) randomValue(t reflect.Type) (any, bool) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return time.Unix(f.rand.Int63n(4102444800), 0).UTC(), true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return f.rand.Intn(2) == 1, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t.Bits() == 64 {
+			return f.rand.Int63(), true
+		}
+		return f.rand.Int63n(1 << (t.Bits() - 1)), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if t.Bits() == 64 {
+			return f.rand.Uint64(), true
+		}
+		return f.rand.Int63n(1 << t.Bits()), true
+	case reflect.Float32, reflect.Float64:
+		return f.rand.Float64() * 1000, true
+	case reflect.String:
+		return f.randomString(), true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return []byte(f.randomString()), true
+		}
+	}
+
+	return nil, false
+}
+
+func (f *Factory[T]) randomString() string {
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = letters[f.rand.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,102 @@
+package factory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+type status string
+
+type nested struct {
+	A int
+}
+
+type user struct {
+	ID        int64
+	Name      goption.Option[string]
+	Age       goption.Option[uint8]
+	Score     goption.Option[float64]
+	Active    goption.Option[bool]
+	Status    goption.Option[status]
+	CreatedAt goption.Option[time.Time]
+	Nested    goption.Option[nested]
+	hidden    goption.Option[int]
+}
+
+func TestBuildLeavesOptionsEmpty(t *testing.T) {
+	u := NewFactory[user]().Set("ID", int64(1)).Set("Name", goption.Some("ola")).Build()
+	if u.ID != 1 || u.Name.Unwrap() != "ola" {
+		t.Errorf("Expected set fields, got %v", u)
+	}
+	if u.Age.Ok() || u.Score.Ok() || u.Active.Ok() || u.CreatedAt.Ok() {
+		t.Errorf("Expected remaining options to be empty, got %v", u)
+	}
+}
+
+func TestBuildRandom(t *testing.T) {
+	build := func() user {
+		return NewFactory[user]().Set("Name", goption.None[string]()).Random(42).Build()
+	}
+
+	u := build()
+	if u.Name.Ok() {
+		t.Errorf("Expected explicitly set field to be kept, got %v", u.Name)
+	}
+	if !u.Age.Ok() || !u.Score.Ok() || !u.Active.Ok() || !u.Status.Ok() || !u.CreatedAt.Ok() {
+		t.Errorf("Expected random values, got %#v", u)
+	}
+	if len(u.Status.Unwrap()) != 8 {
+		t.Errorf("Expected random status, got %q", u.Status.Unwrap())
+	}
+	if u.Nested.Ok() || u.hidden.Ok() {
+		t.Errorf("Expected unsupported and unexported fields to stay empty")
+	}
+
+	if again := build(); !reflect.DeepEqual(u, again) {
+		t.Errorf("Expected the same seed to build the same value:\n%v\n%v", u, again)
+	}
+}
+
+func TestBuildPanics(t *testing.T) {
+	expectPanic := func(name string, f func()) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		f()
+	}
+
+	expectPanic("unknown field", func() {
+		NewFactory[user]().Set("Missing", 1).Build()
+	})
+	expectPanic("wrong type", func() {
+		NewFactory[user]().Set("Name", "not an option").Build()
+	})
+	expectPanic("not a struct", func() {
+		NewFactory[int]().Build()
+	})
+}
+
+type counters struct {
+	Count  goption.Option[int]
+	Total  goption.Option[int64]
+	Serial goption.Option[uint64]
+	Small  goption.Option[int8]
+	Flags  goption.Option[uint16]
+}
+
+func TestBuildRandom64Bit(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		c := NewFactory[counters]().Random(seed).Build()
+		if !c.Count.Ok() || !c.Total.Ok() || !c.Serial.Ok() || !c.Small.Ok() || !c.Flags.Ok() {
+			t.Fatalf("Expected random values, got %#v", c)
+		}
+		if c.Count.Unwrap() < 0 || c.Total.Unwrap() < 0 || c.Small.Unwrap() < 0 {
+			t.Errorf("Expected non-negative values, got %#v", c)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package goption
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// structField is a field of a struct as named by a struct tag. Fields of
+// embedded structs are promoted like encoding/json promotes them.
+type structField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+}
+
+type fieldCacheKey struct {
+	t    reflect.Type
+	tag  string
+	fold bool
+}
+
+var fieldCache sync.Map // map[fieldCacheKey][]structField
+
+// cachedFields returns the fields of the struct type t named by tag.
+func cachedFields(t reflect.Type, tag string, fold bool) []structField {
+	key := fieldCacheKey{t: t, tag: tag, fold: fold}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.([]structField)
+	}
+
+	cached, _ := fieldCache.LoadOrStore(key, typeFields(t, tag, fold))
+	return cached.([]structField)
+}
+
+// typeFields lists the fields of t in declaration order. Fields are named by
+// tag or else by their Go name, fields tagged "-" are skipped. Untagged
+// embedded structs are traversed; of the fields sharing a name the
+// shallowest wins, a tagged one winning over untagged ones at the same depth.
+// Names left ambiguous are dropped. If fold is set, names differing only in
+// case are the same name.
+func typeFields(t reflect.Type, tag string, fold bool) []structField {
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var fields []structField
+	seen := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	next := []embedded{{t: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+
+		var level []structField
+		for _, e := range current {
+			if visited[e.t] {
+				continue
+			}
+			visited[e.t] = true
+
+			for i := 0; i < e.t.NumField(); i++ {
+				f := e.t.Field(i)
+				ft := f.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if !f.IsExported() && !(f.Anonymous && ft.Kind() == reflect.Struct) {
+					continue
+				}
+
+				name, rest, _ := strings.Cut(f.Tag.Get(tag), ",")
+				if name == "-" && rest == "" {
+					continue
+				}
+
+				index := append(append([]int(nil), e.index...), i)
+				if f.Anonymous && name == "" && isPlainStruct(ft) {
+					next = append(next, embedded{t: ft, index: index})
+					continue
+				}
+				if !f.IsExported() {
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = f.Name
+				}
+				level = append(level, structField{
+					name:      name,
+					index:     index,
+					tagged:    tagged,
+					omitEmpty: strings.Contains(","+rest+",", ",omitempty,"),
+					omitZero:  strings.Contains(","+rest+",", ",omitzero,"),
+				})
+			}
+		}
+
+		byName := make(map[string][]structField)
+		for _, f := range level {
+			name := f.name
+			if fold {
+				name = strings.ToLower(name)
+			}
+			if !seen[name] {
+				byName[name] = append(byName[name], f)
+			}
+		}
+		for name, candidates := range byName {
+			seen[name] = true
+			if f, ok := dominantField(candidates); ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	return fields
+}
+
+// dominantField picks the field named by a name at one depth, if any.
+func dominantField(candidates []structField) (structField, bool) {
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+
+	var winner structField
+	tagged := 0
+	for _, f := range candidates {
+		if f.tagged {
+			winner = f
+			tagged++
+		}
+	}
+
+	return winner, tagged == 1
+}
+
+// fieldByIndex returns the possibly promoted field of v at index. Nil
+// embedded pointers are allocated if alloc is set and they're exported,
+// otherwise an invalid Value is returned for fields behind them.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
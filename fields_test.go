@@ -0,0 +1,74 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+type innerFields struct {
+	A Option[int]
+	B Option[int] `json:"b"`
+	C Option[int]
+}
+
+type otherFields struct {
+	C Option[int]
+	D Option[int] `json:"dee"`
+}
+
+type outerFields struct {
+	innerFields
+	*otherFields
+	A     Option[int] `json:"A"`
+	Inner struct {
+		X Option[int]
+	}
+	skipped Option[int]
+	Ignored Option[int] `json:"-"`
+}
+
+func TestTypeFields(t *testing.T) {
+	var names []string
+	var indexes [][]int
+	for _, f := range typeFields(reflect.TypeOf(outerFields{}), "json", false) {
+		names = append(names, f.name)
+		indexes = append(indexes, f.index)
+	}
+
+	// C is ambiguous between both embedded structs and dropped; A is hidden
+	// by the shallower field.
+	expectedNames := []string{"b", "dee", "A", "Inner"}
+	expectedIndexes := [][]int{{0, 1}, {1, 1}, {2}, {3}}
+	if !reflect.DeepEqual(names, expectedNames) || !reflect.DeepEqual(indexes, expectedIndexes) {
+		t.Errorf("Expected %v at %v, got %v at %v", expectedNames, expectedIndexes, names, indexes)
+	}
+}
+
+func TestTypeFieldsTagPrecedence(t *testing.T) {
+	type tagged struct {
+		Name Option[string] `db:"name"`
+	}
+	type untagged struct {
+		Name Option[string]
+	}
+	type both struct {
+		untagged
+		tagged
+	}
+
+	fields := typeFields(reflect.TypeOf(both{}), "db", true)
+	if len(fields) != 1 || !reflect.DeepEqual(fields[0].index, []int{1, 0}) {
+		t.Errorf("Expected the tagged field to win, got %+v", fields)
+	}
+}
+
+func TestFieldByIndex(t *testing.T) {
+	var v outerFields
+	rv := reflect.ValueOf(&v).Elem()
+	if f := fieldByIndex(rv, []int{1, 1}, false); f.IsValid() {
+		t.Errorf("Expected invalid field behind nil pointer")
+	}
+	if f := fieldByIndex(rv, []int{1, 1}, true); f.IsValid() || v.otherFields != nil {
+		t.Errorf("Expected unexported embedded pointer not to be allocated")
+	}
+}
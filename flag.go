@@ -0,0 +1,42 @@
+package goption
+
+import (
+	"flag"
+	"reflect"
+)
+
+// OptionVar defines a flag named name on fs and returns the Option it's
+// stored in, which stays empty unless the flag is passed, so an unset flag
+// can be told apart from one set to the zero value. Values are parsed with
+// UnmarshalText. Flags of Option[bool] may be passed without a value, like
+// flag.Bool.
+func OptionVar[T any](fs *flag.FlagSet, name string, usage string) *Option[T] {
+	o := new(Option[T])
+	fs.Var(optionFlag[T]{o}, name, usage)
+	return o
+}
+
+// optionFlag implements flag.Value for an Option.
+type optionFlag[T any] struct {
+	o *Option[T]
+}
+
+// String implements flag.Value. The flag package calls it on a zero
+// optionFlag to find out whether the default is the zero value.
+func (f optionFlag[T]) String() string {
+	if f.o == nil || !f.o.ok {
+		return ""
+	}
+	return f.o.String()
+}
+
+// Set implements flag.Value
+func (f optionFlag[T]) Set(s string) error {
+	return f.o.UnmarshalText([]byte(s))
+}
+
+// IsBoolFlag is used by the flag package to allow passing boolean flags
+// without a value.
+func (f optionFlag[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
@@ -0,0 +1,51 @@
+package goption
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptionVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	port := OptionVar[int](fs, "port", "port to listen on")
+	retries := OptionVar[int](fs, "retries", "")
+	debug := OptionVar[bool](fs, "debug", "")
+	since := OptionVar[time.Time](fs, "since", "")
+
+	if err := fs.Parse([]string{"-port", "0", "-debug", "-since", "2024-01-02T03:04:05Z"}); err != nil {
+		t.Fatalf("Failed parsing: %s", err)
+	}
+	if !port.Ok() || port.Unwrap() != 0 {
+		t.Errorf("Expected Some(0), got %v", port)
+	}
+	if retries.Ok() {
+		t.Errorf("Expected unset flag to be empty, got %v", retries)
+	}
+	if !debug.Unwrap() {
+		t.Errorf("Expected debug to be true, got %v", debug)
+	}
+	if since.Unwrap().Year() != 2024 {
+		t.Errorf("Expected time to be parsed, got %v", since)
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	OptionVar[int](fs, "port", "")
+	if err := fs.Parse([]string{"-port", "x"}); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+}
+
+func TestOptionVarUsage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var b strings.Builder
+	fs.SetOutput(&b)
+	OptionVar[string](fs, "name", "the `name` to use")
+	fs.PrintDefaults()
+	if got := b.String(); !strings.Contains(got, "-name name") || strings.Contains(got, "default") {
+		t.Errorf("Expected usage without a default, got %q", got)
+	}
+}
@@ -1,10 +1,20 @@
 module github.com/olachat/goption
 
-go 1.19
+go 1.21
 
 require (
 	github.com/fergusstrange/embedded-postgres v1.20.0
+	github.com/fxamacker/cbor/v2 v2.7.0
 	github.com/lib/pq v1.10.7
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	go.mongodb.org/mongo-driver/v2 v2.1.0
+	golang.org/x/text v0.22.0 // v0.20.0 or newer is required by go.mongodb.org/mongo-driver/v2
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require github.com/xi2/xz v0.0.0-20171230120015-48954b6210f8 // indirect
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	github.com/xi2/xz v0.0.0-20171230120015-48954b6210f8 // indirect
+)
@@ -0,0 +1,58 @@
+// Package goptionbson stores goption.Options in MongoDB documents with
+// go.mongodb.org/mongo-driver/v2/bson, as BSON null when empty and as their
+// value when present.
+package goptionbson
+
+import (
+	"github.com/olachat/goption"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Option is a goption.Option implementing bson.ValueMarshaler and
+// bson.ValueUnmarshaler. Empty options are written as null, or omitted from
+// documents if the field is tagged omitempty. Null, undefined and missing
+// fields decode as empty options.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Some returns an Option holding t.
+func Some[T any](t T) Option[T] {
+	return Option[T]{goption.Some(t)}
+}
+
+// None returns an empty Option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// Of wraps o.
+func Of[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler
+func (o Option[T]) MarshalBSONValue() (byte, []byte, error) {
+	t, ok := o.Get()
+	if !ok {
+		return byte(bson.TypeNull), nil, nil
+	}
+
+	typ, data, err := bson.MarshalValue(t)
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler
+func (o *Option[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) == bson.TypeNull || bson.Type(typ) == bson.TypeUndefined {
+		o.Option = goption.None[T]()
+		return nil
+	}
+
+	var t T
+	if err := (bson.RawValue{Type: bson.Type(typ), Value: data}).Unmarshal(&t); err != nil {
+		return err
+	}
+	o.Option = goption.Some(t)
+	return nil
+}
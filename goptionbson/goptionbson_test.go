@@ -0,0 +1,65 @@
+package goptionbson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type document struct {
+	Name    Option[string]    `bson:"name"`
+	Age     Option[int64]     `bson:"age"`
+	Seen    Option[time.Time] `bson:"seen"`
+	Tags    Option[[]string]  `bson:"tags"`
+	Comment Option[string]    `bson:"comment,omitempty"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	seen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := document{
+		Name: Some("ada"),
+		Seen: Some(seen),
+		Tags: Of(goption.Some([]string{"a", "b"})),
+	}
+
+	b, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	out := document{Age: Some(int64(1)), Comment: Some("stale")}
+	if err := bson.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+
+	if out.Name.Unwrap() != "ada" || out.Age.Ok() || !out.Seen.Unwrap().Equal(seen) || len(out.Tags.Unwrap()) != 2 {
+		t.Errorf("Unexpected round trip: %+v", out)
+	}
+}
+
+func TestNoneIsNull(t *testing.T) {
+	b, err := bson.Marshal(document{Name: None[string]()})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+
+	raw := bson.Raw(b)
+	if v := raw.Lookup("name"); v.Type != bson.TypeNull {
+		t.Errorf("Expected null, got %s", v.Type)
+	}
+	if _, err := raw.LookupErr("comment"); err == nil {
+		t.Errorf("Expected empty omitempty field to be omitted")
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	b, err := bson.Marshal(bson.D{{Key: "age", Value: "old"}})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	var d document
+	if err := bson.Unmarshal(b, &d); err == nil {
+		t.Errorf("Expected error decoding a string into an int64 option")
+	}
+}
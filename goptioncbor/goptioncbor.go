@@ -0,0 +1,62 @@
+// Package goptioncbor encodes goption.Options with github.com/fxamacker/cbor,
+// for COSE and other CBOR based protocols.
+package goptioncbor
+
+import (
+	"bytes"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/olachat/goption"
+)
+
+// Option is a goption.Option implementing cbor.Marshaler and
+// cbor.Unmarshaler. Empty options are encoded as CBOR null. Null and
+// undefined decode as empty options, like map keys which are absent.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Some returns an Option holding t.
+func Some[T any](t T) Option[T] {
+	return Option[T]{goption.Some(t)}
+}
+
+// None returns an empty Option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// Of wraps o.
+func Of[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+var (
+	cborNull      = []byte{0xf6}
+	cborUndefined = []byte{0xf7}
+)
+
+// MarshalCBOR implements cbor.Marshaler
+func (o Option[T]) MarshalCBOR() ([]byte, error) {
+	t, ok := o.Get()
+	if !ok {
+		return cborNull, nil
+	}
+
+	return cbor.Marshal(t)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler
+func (o *Option[T]) UnmarshalCBOR(data []byte) error {
+	if bytes.Equal(data, cborNull) || bytes.Equal(data, cborUndefined) {
+		o.Option = goption.None[T]()
+		return nil
+	}
+
+	var t T
+	if err := cbor.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	o.Option = goption.Some(t)
+	return nil
+}
@@ -0,0 +1,64 @@
+package goptioncbor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/olachat/goption"
+)
+
+type claims struct {
+	Issuer   Option[string]   `cbor:"1,keyasint"`
+	Expires  Option[int64]    `cbor:"4,keyasint"`
+	Audience Option[[]string] `cbor:"3,keyasint"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := claims{
+		Issuer:   Some("issuer"),
+		Audience: Of(goption.Some([]string{"a"})),
+	}
+
+	b, err := cbor.Marshal(in)
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	var out claims
+	if err := cbor.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if out.Issuer.Unwrap() != "issuer" || out.Expires.Ok() || len(out.Audience.Unwrap()) != 1 {
+		t.Errorf("Unexpected round trip: %+v", out)
+	}
+}
+
+func TestNoneIsNull(t *testing.T) {
+	b, err := None[int64]().MarshalCBOR()
+	if err != nil || !bytes.Equal(b, []byte{0xf6}) {
+		t.Errorf("Expected CBOR null, got %x (%v)", b, err)
+	}
+
+	o := Some(int64(1))
+	if err := cbor.Unmarshal([]byte{0xf7}, &o); err != nil || o.Ok() {
+		t.Errorf("Expected undefined to decode as empty, got %v (%v)", o, err)
+	}
+}
+
+func TestAbsentKeys(t *testing.T) {
+	b, err := cbor.Marshal(map[int]any{1: "issuer"})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	var out claims
+	if err := cbor.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if out.Issuer.Unwrap() != "issuer" || out.Expires.Ok() || out.Audience.Ok() {
+		t.Errorf("Expected absent keys to be empty, got %+v", out)
+	}
+
+	if err := cbor.Unmarshal([]byte{0xa1, 0x04, 0x61, 'x'}, &out); err == nil {
+		t.Errorf("Expected error decoding a string into an int64 option")
+	}
+}
@@ -0,0 +1,65 @@
+package goptiongen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestParseEnumType(t *testing.T) {
+	e, err := ParseEnumType("github.com/acme/api.v2/pb.Status")
+	if err != nil || e.ImportPath != "github.com/acme/api.v2/pb" || e.Name != "Status" {
+		t.Errorf("Unexpected enum type %+v (%v)", e, err)
+	}
+
+	for _, s := range []string{"Status", "github.com/acme/pb", "github.com/acme/pb.status", "pb.", ".Status"} {
+		if _, err := ParseEnumType(s); err == nil {
+			t.Errorf("Expected error for %q", s)
+		}
+	}
+}
+
+func TestGenerateEnums(t *testing.T) {
+	var b bytes.Buffer
+	err := GenerateEnums(&b, "models", []EnumType{
+		{ImportPath: "github.com/acme/api/pb", Name: "Status"},
+		{ImportPath: "github.com/acme/api/pb", Name: "Role"},
+		{ImportPath: "github.com/acme/legacy/pb", Name: "Plan"},
+		{ImportPath: "github.com/acme/json", Name: "Kind"},
+	})
+	if err != nil {
+		t.Fatalf("Failed generating: %s", err)
+	}
+	src := b.String()
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "enums.go", src, 0); err != nil {
+		t.Fatalf("Expected valid Go source, got %s:\n%s", err, src)
+	}
+	for _, expected := range []string{
+		"// Code generated by goption-gen. DO NOT EDIT.",
+		"\tpb \"github.com/acme/api/pb\"",
+		"\tpb2 \"github.com/acme/legacy/pb\"",
+		"\tjson2 \"github.com/acme/json\"",
+		"type StatusOption struct {\n\tgoption.Option[pb.Status]\n}",
+		"type PlanOption struct {\n\tgoption.Option[pb2.Plan]\n}",
+		"if n, ok := pb.Status_value[text]; ok {",
+		"if name, ok := json2.Kind_name[int32(v)]; ok {",
+		"func (o *RoleOption) Scan(src any) error {",
+		"func (o StatusOption) Value() (driver.Value, error) {",
+		"func (o *KindOption) UnmarshalJSON(data []byte) error {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected generated source to contain %q:\n%s", expected, src)
+		}
+	}
+
+	err = GenerateEnums(&b, "models", []EnumType{
+		{ImportPath: "github.com/acme/a", Name: "Status"},
+		{ImportPath: "github.com/acme/b", Name: "Status"},
+	})
+	if err == nil {
+		t.Errorf("Expected error for colliding enum names")
+	}
+}
@@ -0,0 +1,236 @@
+// Package goptiongen generates model structs from database schemas, as read
+// from information_schema. Nullable columns become goption.Option fields, and
+// every struct gets a function scanning rows into it with a goption.ScanPlan.
+// It also generates Option codecs specialized for protobuf enums. The
+// goption-gen command runs it.
+package goptiongen
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Column describes a column of a table.
+type Column struct {
+	Table    string
+	Name     string
+	DataType string
+	Nullable bool
+}
+
+// columnsQuery lists the columns of a schema in table and column order.
+const columnsQuery = `SELECT table_name, column_name, data_type, is_nullable
+FROM information_schema.columns
+WHERE table_schema = %s
+ORDER BY table_name, ordinal_position`
+
+// LoadColumns returns the columns of the tables of schema from
+// information_schema.columns. placeholder is the placeholder of the first
+// query argument of the driver, e.g. "$1" for PostgreSQL or "?" for MySQL.
+func LoadColumns(ctx context.Context, db *sql.DB, schema, placeholder string) ([]Column, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(columnsQuery, placeholder), schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var c Column
+		var nullable string
+		if err := rows.Scan(&c.Table, &c.Name, &c.DataType, &nullable); err != nil {
+			return nil, err
+		}
+		c.Nullable = strings.EqualFold(nullable, "YES")
+		columns = append(columns, c)
+	}
+
+	return columns, rows.Err()
+}
+
+// goTypes maps lowercased information_schema data types to Go types. Other
+// data types are generated as strings.
+var goTypes = map[string]string{
+	"boolean":                     "bool",
+	"bool":                        "bool",
+	"tinyint":                     "int8",
+	"smallint":                    "int16",
+	"mediumint":                   "int32",
+	"int":                         "int32",
+	"integer":                     "int32",
+	"bigint":                      "int64",
+	"real":                        "float32",
+	"float":                       "float32",
+	"double":                      "float64",
+	"double precision":            "float64",
+	"date":                        "time.Time",
+	"datetime":                    "time.Time",
+	"timestamp":                   "time.Time",
+	"timestamp without time zone": "time.Time",
+	"timestamp with time zone":    "time.Time",
+	"bytea":                       "[]byte",
+	"binary":                      "[]byte",
+	"varbinary":                   "[]byte",
+	"blob":                        "[]byte",
+	"tinyblob":                    "[]byte",
+	"mediumblob":                  "[]byte",
+	"longblob":                    "[]byte",
+}
+
+// goType returns the type of the field of c.
+func goType(c Column) string {
+	t, ok := goTypes[strings.ToLower(c.DataType)]
+	if !ok {
+		t = "string"
+	}
+	if c.Nullable {
+		return "goption.Option[" + t + "]"
+	}
+
+	return t
+}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true,
+}
+
+// goName returns the exported Go name of the snake case name, e.g. UserID
+// for user_id.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+
+	if b.Len() == 0 || !unicode.IsLetter([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// Generate writes the source of package pkg, which declares a struct and a
+// scan function for every table of columns, to w. Tables are generated in
+// the order of their first column. Tables or columns of a table whose Go
+// names collide, e.g. user_id and userID, are an error.
+func Generate(w io.Writer, pkg string, columns []Column) error {
+	var tables []string
+	byTable := make(map[string][]Column)
+	usesTime := false
+	for _, c := range columns {
+		if _, ok := byTable[c.Table]; !ok {
+			tables = append(tables, c.Table)
+		}
+		byTable[c.Table] = append(byTable[c.Table], c)
+		usesTime = usesTime || strings.Contains(goType(c), "time.Time")
+	}
+
+	// declared maps the package level names of the generated code to the
+	// tables declaring them.
+	declared := make(map[string]string)
+	for _, table := range tables {
+		name := goName(table)
+		for _, decl := range []string{name, "Scan" + name, "scan" + name + "Plans", "scan" + name + "Plan"} {
+			if other, ok := declared[decl]; ok {
+				return fmt.Errorf("tables %s and %s both generate %s", other, table, decl)
+			}
+			declared[decl] = table
+		}
+
+		fields := make(map[string]string)
+		for _, c := range byTable[table] {
+			field := goName(c.Name)
+			if other, ok := fields[field]; ok {
+				return fmt.Errorf("columns %s and %s of table %s both generate field %s", other, c.Name, table, field)
+			}
+			fields[field] = c.Name
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by goption-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n\t\"database/sql\"\n\t\"strings\"\n\t\"sync\"\n", pkg)
+	if usesTime {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString("\n\t\"github.com/olachat/goption\"\n)\n")
+
+	for _, table := range tables {
+		name := goName(table)
+		fmt.Fprintf(&buf, "\n// %s is a row of the table %s.\ntype %s struct {\n", name, table, name)
+		for _, c := range byTable[table] {
+			fmt.Fprintf(&buf, "\t%s %s `db:%q`\n", goName(c.Name), goType(c), c.Name)
+		}
+		buf.WriteString("}\n")
+
+		fmt.Fprintf(&buf, `
+// scan%[1]sPlans caches the goption.ScanPlan of every set of columns
+// scanned into %[1]s, keyed by the column names.
+var scan%[1]sPlans sync.Map
+
+// scan%[1]sPlan returns the cached goption.ScanPlan of columns, compiling
+// it on first use.
+func scan%[1]sPlan(columns []*sql.ColumnType) (*goption.ScanPlan[%[1]s], error) {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name()
+	}
+	key := strings.Join(names, "\x00")
+	if plan, ok := scan%[1]sPlans.Load(key); ok {
+		return plan.(*goption.ScanPlan[%[1]s]), nil
+	}
+
+	plan, err := goption.NewScanPlan[%[1]s](columns)
+	if err != nil {
+		return nil, err
+	}
+	scan%[1]sPlans.Store(key, plan)
+	return plan, nil
+}
+
+// Scan%[1]s scans the remaining rows of rows into %[1]s structs with a
+// goption.ScanPlan compiled once per set of columns. It doesn't close rows.
+func Scan%[1]s(rows *sql.Rows) ([]%[1]s, error) {
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := scan%[1]sPlan(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []%[1]s
+	for rows.Next() {
+		var row %[1]s
+		if err := plan.Scan(rows, &row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+`, name)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting generated source: %w", err)
+	}
+
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,181 @@
+package goptiongen
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testColumns = []Column{
+	{Table: "users", Name: "id", DataType: "bigint"},
+	{Table: "users", Name: "email", DataType: "text", Nullable: true},
+	{Table: "users", Name: "created_at", DataType: "timestamp with time zone"},
+	{Table: "users", Name: "avatar_url", DataType: "character varying", Nullable: true},
+	{Table: "order_items", Name: "order_id", DataType: "integer"},
+	{Table: "order_items", Name: "price", DataType: "DOUBLE PRECISION", Nullable: true},
+}
+
+func TestGenerate(t *testing.T) {
+	var b bytes.Buffer
+	if err := Generate(&b, "models", testColumns); err != nil {
+		t.Fatalf("Failed generating: %s", err)
+	}
+	src := b.String()
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "models.go", src, 0); err != nil {
+		t.Fatalf("Expected valid Go source, got %s:\n%s", err, src)
+	}
+	for _, expected := range []string{
+		"// Code generated by goption-gen. DO NOT EDIT.",
+		"package models",
+		"\t\"time\"",
+		"type Users struct {",
+		"ID        int64                  `db:\"id\"`",
+		"Email     goption.Option[string] `db:\"email\"`",
+		"CreatedAt time.Time              `db:\"created_at\"`",
+		"AvatarURL goption.Option[string] `db:\"avatar_url\"`",
+		"func ScanUsers(rows *sql.Rows) ([]Users, error) {",
+		"var scanUsersPlans sync.Map",
+		"goption.NewScanPlan[Users](columns)",
+		"plan, err := scanUsersPlan(columns)",
+		"type OrderItems struct {",
+		"Price   goption.Option[float64] `db:\"price\"`",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected generated source to contain %q:\n%s", expected, src)
+		}
+	}
+	if strings.Index(src, "type Users") > strings.Index(src, "type OrderItems") {
+		t.Errorf("Expected tables in the order of their columns:\n%s", src)
+	}
+
+	b.Reset()
+	if err := Generate(&b, "models", testColumns[4:5]); err != nil || strings.Contains(b.String(), "\"time\"") {
+		t.Errorf("Expected no time import without time columns, got %v:\n%s", err, b.String())
+	}
+}
+
+func TestGenerateCollisions(t *testing.T) {
+	for _, tc := range []struct {
+		columns  []Column
+		expected string
+	}{
+		{
+			columns:  []Column{{Table: "users", Name: "user_id"}, {Table: "users", Name: "userID"}},
+			expected: "columns user_id and userID of table users both generate field UserID",
+		},
+		{
+			columns:  []Column{{Table: "users", Name: "id"}, {Table: "Users", Name: "id"}},
+			expected: "tables users and Users both generate Users",
+		},
+		{
+			columns:  []Column{{Table: "users", Name: "id"}, {Table: "scan_users", Name: "id"}},
+			expected: "tables users and scan_users both generate ScanUsers",
+		},
+	} {
+		if err := Generate(io.Discard, "models", tc.columns); err == nil || err.Error() != tc.expected {
+			t.Errorf("Expected %q, got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"user_id":   "UserID",
+		"CamelCase": "CamelCase",
+		"api-key":   "APIKey",
+		"2fa":       "X2fa",
+		"":          "X",
+	} {
+		if got := goName(name); got != expected {
+			t.Errorf("Expected %s for %q, got %s", expected, name, got)
+		}
+	}
+}
+
+// schemaDriver returns the columns of testColumns for every query, recording
+// the query and its arguments.
+type schemaDriver struct {
+	query *string
+	args  *[]driver.NamedValue
+}
+
+func (d schemaDriver) Open(string) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d schemaDriver) Prepare(string) (driver.Stmt, error) {
+	panic("unused")
+}
+
+func (d schemaDriver) Close() error {
+	return nil
+}
+
+func (d schemaDriver) Begin() (driver.Tx, error) {
+	panic("unused")
+}
+
+func (d schemaDriver) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	*d.query, *d.args = query, args
+	return &schemaRows{}, nil
+}
+
+type schemaRows struct {
+	next int
+}
+
+func (r *schemaRows) Columns() []string {
+	return []string{"table_name", "column_name", "data_type", "is_nullable"}
+}
+
+func (r *schemaRows) Close() error {
+	return nil
+}
+
+func (r *schemaRows) Next(dest []driver.Value) error {
+	if r.next == len(testColumns) {
+		return io.EOF
+	}
+	c := testColumns[r.next]
+	r.next++
+
+	nullable := "NO"
+	if c.Nullable {
+		nullable = "YES"
+	}
+	dest[0], dest[1], dest[2], dest[3] = c.Table, c.Name, c.DataType, nullable
+	return nil
+}
+
+func TestLoadColumns(t *testing.T) {
+	var query string
+	var args []driver.NamedValue
+	sql.Register("goptiongen-schema", schemaDriver{query: &query, args: &args})
+	db, err := sql.Open("goptiongen-schema", "")
+	if err != nil {
+		t.Fatalf("Failed opening: %s", err)
+	}
+	defer db.Close()
+
+	columns, err := LoadColumns(context.Background(), db, "public", "$1")
+	if err != nil {
+		t.Fatalf("Failed loading columns: %s", err)
+	}
+	if !reflect.DeepEqual(columns, testColumns) {
+		t.Errorf("Expected %v, got %v", testColumns, columns)
+	}
+	if !strings.Contains(query, "information_schema.columns") || !strings.Contains(query, "table_schema = $1") {
+		t.Errorf("Unexpected query %q", query)
+	}
+	if len(args) != 1 || args[0].Value != "public" {
+		t.Errorf("Expected the schema as the only argument, got %v", args)
+	}
+}
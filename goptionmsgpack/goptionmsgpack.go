@@ -0,0 +1,48 @@
+// Package goptionmsgpack encodes goption.Options with
+// github.com/vmihailenco/msgpack, writing empty options as nil and present
+// ones as their value, so payload structs of msgpack RPC can have Option
+// fields.
+package goptionmsgpack
+
+import (
+	"reflect"
+
+	"github.com/olachat/goption"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// Register makes msgpack encode and decode Options of T, including ones in
+// struct fields, slices and maps. Nil decodes as an empty option. Since
+// msgpack registrations are global, it's meant to be called during
+// initialization.
+func Register[T any]() {
+	msgpack.Register(goption.Option[T]{}, encode[T], decode[T])
+}
+
+func encode[T any](enc *msgpack.Encoder, v reflect.Value) error {
+	t, ok := v.Interface().(goption.Option[T]).Get()
+	if !ok {
+		return enc.EncodeNil()
+	}
+
+	return enc.Encode(t)
+}
+
+func decode[T any](dec *msgpack.Decoder, v reflect.Value) error {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == msgpcode.Nil {
+		v.Set(reflect.ValueOf(goption.None[T]()))
+		return dec.DecodeNil()
+	}
+
+	var t T
+	if err := dec.Decode(&t); err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(goption.Some(t)))
+	return nil
+}
@@ -0,0 +1,82 @@
+package goptionmsgpack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func init() {
+	Register[string]()
+	Register[int64]()
+	Register[time.Time]()
+	Register[[]int]()
+}
+
+type payload struct {
+	Name    goption.Option[string]
+	Age     goption.Option[int64]
+	Seen    goption.Option[time.Time]
+	Scores  goption.Option[[]int]
+	Aliases []goption.Option[string]
+	Hidden  goption.Option[string] `msgpack:",omitempty"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	seen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := payload{
+		Name:    goption.Some("ada"),
+		Seen:    goption.Some(seen),
+		Scores:  goption.Some([]int{1, 2}),
+		Aliases: []goption.Option[string]{goption.None[string](), goption.Some("a")},
+	}
+
+	b, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	out := payload{Age: goption.Some(int64(1))}
+	if err := msgpack.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+
+	if out.Name.Unwrap() != "ada" || out.Age.Ok() || !out.Seen.Unwrap().Equal(seen) || len(out.Scores.Unwrap()) != 2 ||
+		len(out.Aliases) != 2 || out.Aliases[0].Ok() || out.Aliases[1].Unwrap() != "a" || out.Hidden.Ok() {
+		t.Errorf("Unexpected round trip: %+v", out)
+	}
+}
+
+func TestNoneIsNil(t *testing.T) {
+	b, err := msgpack.Marshal(map[string]goption.Option[int64]{"age": goption.None[int64]()})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	var m map[string]any
+	if err := msgpack.Unmarshal(b, &m); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if v, ok := m["age"]; !ok || v != nil {
+		t.Errorf("Expected nil, got %#v", m)
+	}
+
+	var o goption.Option[int64]
+	if err := msgpack.Unmarshal([]byte{0xa1, 'x'}, &o); err == nil {
+		t.Errorf("Expected error decoding a string into an int64 option")
+	}
+}
+
+func TestOmitEmpty(t *testing.T) {
+	b, err := msgpack.Marshal(payload{})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	var m map[string]any
+	if err := msgpack.Unmarshal(b, &m); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if _, ok := m["Hidden"]; ok {
+		t.Errorf("Expected empty option to be omitted, got %v", m)
+	}
+}
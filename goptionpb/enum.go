@@ -0,0 +1,141 @@
+package goptionpb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/olachat/goption"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProtoEnum is satisfied by generated protobuf enum types.
+type ProtoEnum interface {
+	~int32
+	protoreflect.Enum
+}
+
+// Enum is an Option of a protobuf enum which is scanned from integer or
+// name columns, written to databases as its number and encoded to JSON as
+// its name, like protojson does. Numbers without a name are kept, since
+// protobuf enums are open, and encoded to JSON as numbers. goption-gen -enum
+// generates the same codec specialized for an enum type.
+type Enum[E ProtoEnum] struct {
+	goption.Option[E]
+}
+
+// SomeEnum returns an Enum holding e.
+func SomeEnum[E ProtoEnum](e E) Enum[E] {
+	return Enum[E]{Option: goption.Some(e)}
+}
+
+// NoneEnum returns an empty Enum.
+func NoneEnum[E ProtoEnum]() Enum[E] {
+	return Enum[E]{}
+}
+
+// EnumOf wraps o as an Enum.
+func EnumOf[E ProtoEnum](o goption.Option[E]) Enum[E] {
+	return Enum[E]{Option: o}
+}
+
+// parseEnum returns the enum named or numbered by text.
+func parseEnum[E ProtoEnum](text string) (E, error) {
+	var zero E
+	if v := zero.Descriptor().Values().ByName(protoreflect.Name(text)); v != nil {
+		return E(v.Number()), nil
+	}
+
+	n, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return zero, fmt.Errorf("unknown %s value %q", zero.Descriptor().FullName(), text)
+	}
+	return E(n), nil
+}
+
+// Scan implements sql.Scanner
+func (e *Enum[E]) Scan(src any) error {
+	var v E
+	switch s := src.(type) {
+	case nil:
+		e.Option = goption.None[E]()
+		return nil
+	case int64:
+		if s < math.MinInt32 || s > math.MaxInt32 {
+			return fmt.Errorf("enum number %d out of range", s)
+		}
+		v = E(s)
+	case string:
+		parsed, err := parseEnum[E](s)
+		if err != nil {
+			return err
+		}
+		v = parsed
+	case []byte:
+		parsed, err := parseEnum[E](string(s))
+		if err != nil {
+			return err
+		}
+		v = parsed
+	default:
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, v)
+	}
+
+	e.Option = goption.Some(v)
+	return nil
+}
+
+// Value implements driver.Valuer
+func (e Enum[E]) Value() (driver.Value, error) {
+	v, ok := e.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	return int64(v.Number()), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e Enum[E]) MarshalJSON() ([]byte, error) {
+	v, ok := e.Get()
+	if !ok {
+		return []byte("null"), nil
+	}
+
+	if desc := v.Descriptor().Values().ByNumber(v.Number()); desc != nil {
+		return json.Marshal(string(desc.Name()))
+	}
+	return strconv.AppendInt(nil, int64(v.Number()), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *Enum[E]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		e.Option = goption.None[E]()
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		v, err := parseEnum[E](name)
+		if err != nil {
+			return err
+		}
+		e.Option = goption.Some(v)
+		return nil
+	}
+
+	var n int32
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	e.Option = goption.Some(E(n))
+	return nil
+}
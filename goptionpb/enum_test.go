@@ -0,0 +1,74 @@
+package goptionpb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type fieldType = descriptorpb.FieldDescriptorProto_Type
+
+func TestEnumScan(t *testing.T) {
+	for _, src := range []any{int64(9), "TYPE_STRING", []byte("TYPE_STRING"), "9"} {
+		var e Enum[fieldType]
+		if err := e.Scan(src); err != nil {
+			t.Errorf("Failed scanning %#v: %s", src, err)
+		} else if e.Unwrap() != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+			t.Errorf("Expected TYPE_STRING scanning %#v, got %v", src, e)
+		}
+	}
+
+	e := SomeEnum(descriptorpb.FieldDescriptorProto_TYPE_BOOL)
+	if err := e.Scan(nil); err != nil || e.Ok() {
+		t.Errorf("Expected NULL to scan as empty, got %v (%v)", e, err)
+	}
+
+	for _, src := range []any{"TYPE_NOPE", int64(1 << 40), 1.5} {
+		var e Enum[fieldType]
+		if err := e.Scan(src); err == nil {
+			t.Errorf("Expected error scanning %#v, got %v", src, e)
+		}
+	}
+}
+
+func TestEnumValue(t *testing.T) {
+	if v, err := SomeEnum(descriptorpb.FieldDescriptorProto_TYPE_BYTES).Value(); err != nil || v != int64(12) {
+		t.Errorf("Expected 12, got %#v (%v)", v, err)
+	}
+	if v, err := NoneEnum[fieldType]().Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, got %#v (%v)", v, err)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	type row struct {
+		Type    Enum[fieldType]
+		Unknown Enum[fieldType]
+		Missing Enum[fieldType]
+	}
+	in := row{
+		Type:    SomeEnum(descriptorpb.FieldDescriptorProto_TYPE_INT64),
+		Unknown: SomeEnum(fieldType(100)),
+	}
+
+	encoded, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+	if string(encoded) != `{"Type":"TYPE_INT64","Unknown":100,"Missing":null}` {
+		t.Errorf("Unexpected encoded data: %s", encoded)
+	}
+
+	var out row
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if out != in {
+		t.Errorf("Expected %v, got %v", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Type":"TYPE_NOPE"}`), &out); err == nil {
+		t.Errorf("Expected error for unknown enum name")
+	}
+}
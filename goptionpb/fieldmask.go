@@ -0,0 +1,141 @@
+// Package goptionpb bridges structs of goption.Option fields with protocol
+// buffer conventions.
+package goptionpb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/olachat/goption"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type oker interface {
+	Ok() bool
+}
+
+// FieldMaskOf returns a FieldMask with the paths of the present Option fields
+// of patch, a struct or pointer to a struct. Fields are named by protobuf
+// tags, else by json tags, else by their Go name. Fields of nested structs
+// have dotted paths, fields of embedded structs are promoted.
+func FieldMaskOf(patch any) *fieldmaskpb.FieldMask {
+	mask := &fieldmaskpb.FieldMask{}
+	rv := reflect.ValueOf(patch)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return mask
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return mask
+	}
+
+	walkOptions(rv, "", func(path string, opt reflect.Value) {
+		if opt.Interface().(oker).Ok() {
+			mask.Paths = append(mask.Paths, path)
+		}
+	})
+	return mask
+}
+
+// ApplyFieldMask empties the Option fields of patch, a pointer to a struct,
+// which mask doesn't cover. A path covers a field if it's the field's path or
+// the path of a struct it's nested in. Paths of mask naming no Option field
+// or struct are an error, in which case patch is left unchanged.
+func ApplyFieldMask(patch any, mask *fieldmaskpb.FieldMask) error {
+	rv := reflect.ValueOf(patch)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported patch type %T, must be a pointer to a struct", patch)
+	}
+	rv = rv.Elem()
+
+	known := make(map[string]bool)
+	walkOptions(rv, "", func(path string, _ reflect.Value) {
+		for {
+			known[path] = true
+			i := strings.LastIndexByte(path, '.')
+			if i < 0 {
+				break
+			}
+			path = path[:i]
+		}
+	})
+	for _, path := range mask.GetPaths() {
+		if !known[path] {
+			return fmt.Errorf("field mask path %q doesn't name a field of %s", path, rv.Type())
+		}
+	}
+
+	walkOptions(rv, "", func(path string, opt reflect.Value) {
+		if !covers(mask.GetPaths(), path) {
+			opt.SetZero()
+		}
+	})
+	return nil
+}
+
+// covers reports whether any of paths is path or a parent of it.
+func covers(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// walkOptions calls f with every Option field of rv and its path.
+func walkOptions(rv reflect.Value, prefix string, f func(path string, opt reflect.Value)) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := rv.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv, ft = fv.Elem(), ft.Elem()
+		}
+
+		name := fieldName(sf)
+		if name == "-" {
+			continue
+		}
+		switch {
+		case goption.IsOptionType(ft):
+			if sf.IsExported() {
+				f(prefix+name, fv)
+			}
+		case ft.Kind() == reflect.Struct && ft != timeType:
+			if sf.Anonymous {
+				walkOptions(fv, prefix, f)
+			} else if sf.IsExported() {
+				walkOptions(fv, prefix+name+".", f)
+			}
+		}
+	}
+}
+
+// fieldName returns the name of a field in field mask paths.
+func fieldName(sf reflect.StructField) string {
+	for _, part := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name
+		}
+	}
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+
+	return sf.Name
+}
@@ -0,0 +1,84 @@
+package goptionpb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/olachat/goption"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type audit struct {
+	UpdatedBy goption.Option[string] `json:"updated_by"`
+}
+
+type address struct {
+	City goption.Option[string] `protobuf:"bytes,1,opt,name=city,proto3" json:"cityName"`
+	Zip  goption.Option[string] `json:"zip"`
+}
+
+type userPatch struct {
+	audit
+	DisplayName goption.Option[string] `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3"`
+	Age         goption.Option[int]    `json:"age,omitempty"`
+	Address     address                `json:"address"`
+	Ignored     goption.Option[int]    `json:"-"`
+	Plain       string
+}
+
+func TestFieldMaskOf(t *testing.T) {
+	patch := userPatch{
+		audit:       audit{UpdatedBy: goption.Some("admin")},
+		DisplayName: goption.Some("Ada"),
+		Address:     address{City: goption.Some("London")},
+		Ignored:     goption.Some(1),
+	}
+
+	mask := FieldMaskOf(&patch)
+	expected := []string{"updated_by", "display_name", "address.city"}
+	if !reflect.DeepEqual(mask.Paths, expected) {
+		t.Errorf("Expected paths %v, got %v", expected, mask.Paths)
+	}
+
+	if paths := FieldMaskOf(userPatch{}).Paths; len(paths) != 0 {
+		t.Errorf("Expected no paths for empty patch, got %v", paths)
+	}
+	if paths := FieldMaskOf((*userPatch)(nil)).Paths; len(paths) != 0 {
+		t.Errorf("Expected no paths for nil patch, got %v", paths)
+	}
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	patch := userPatch{
+		audit:       audit{UpdatedBy: goption.Some("admin")},
+		DisplayName: goption.Some("Ada"),
+		Age:         goption.Some(36),
+		Address:     address{City: goption.Some("London"), Zip: goption.Some("N1")},
+		Plain:       "kept",
+	}
+
+	if err := ApplyFieldMask(&patch, &fieldmaskpb.FieldMask{Paths: []string{"age", "address"}}); err != nil {
+		t.Fatalf("Failed applying mask: %s", err)
+	}
+
+	if patch.UpdatedBy.Ok() || patch.DisplayName.Ok() {
+		t.Errorf("Expected fields outside the mask to be cleared, got %+v", patch)
+	}
+	if patch.Age.Unwrap() != 36 || patch.Address.City.Unwrap() != "London" || patch.Address.Zip.Unwrap() != "N1" {
+		t.Errorf("Expected fields in the mask to be kept, got %+v", patch)
+	}
+	if patch.Plain != "kept" {
+		t.Errorf("Expected non-Option fields to be kept, got %q", patch.Plain)
+	}
+
+	before := patch
+	if err := ApplyFieldMask(&patch, &fieldmaskpb.FieldMask{Paths: []string{"nope"}}); err == nil {
+		t.Errorf("Expected error for unknown path")
+	}
+	if !reflect.DeepEqual(patch, before) {
+		t.Errorf("Expected patch to be unchanged on error, got %+v", patch)
+	}
+	if err := ApplyFieldMask(patch, nil); err == nil {
+		t.Errorf("Expected error for non-pointer patch")
+	}
+}
@@ -0,0 +1,203 @@
+package goptionpb
+
+import (
+	"github.com/olachat/goption"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// FromProto converts a proto3 optional field, which is generated as a
+// pointer, into an Option. It's the same as goption.FromPtr.
+func FromProto[T any](v *T) goption.Option[T] {
+	return goption.FromPtr(v)
+}
+
+// ToProto converts o into a proto3 optional field, nil if o is empty. The
+// value is copied.
+func ToProto[T any](o goption.Option[T]) *T {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// FromProtoString converts a proto3 optional string field into an Option.
+func FromProtoString(v *string) goption.Option[string] {
+	return goption.FromPtr(v)
+}
+
+// ToProtoString converts o into a proto3 optional string field.
+func ToProtoString(o goption.Option[string]) *string {
+	return ToProto(o)
+}
+
+// FromWrappersPBDouble converts a wrapperspb.DoubleValue, nil if unset, into
+// an Option.
+func FromWrappersPBDouble(v *wrapperspb.DoubleValue) goption.Option[float64] {
+	if v == nil {
+		return goption.None[float64]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBDouble converts o into a wrapperspb.DoubleValue, nil if o is
+// empty.
+func ToWrappersPBDouble(o goption.Option[float64]) *wrapperspb.DoubleValue {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Double(v)
+}
+
+// FromWrappersPBFloat converts a wrapperspb.FloatValue, nil if unset, into
+// an Option.
+func FromWrappersPBFloat(v *wrapperspb.FloatValue) goption.Option[float32] {
+	if v == nil {
+		return goption.None[float32]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBFloat converts o into a wrapperspb.FloatValue, nil if o is
+// empty.
+func ToWrappersPBFloat(o goption.Option[float32]) *wrapperspb.FloatValue {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Float(v)
+}
+
+// FromWrappersPBInt64 converts a wrapperspb.Int64Value, nil if unset, into
+// an Option.
+func FromWrappersPBInt64(v *wrapperspb.Int64Value) goption.Option[int64] {
+	if v == nil {
+		return goption.None[int64]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBInt64 converts o into a wrapperspb.Int64Value, nil if o is
+// empty.
+func ToWrappersPBInt64(o goption.Option[int64]) *wrapperspb.Int64Value {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Int64(v)
+}
+
+// FromWrappersPBUInt64 converts a wrapperspb.UInt64Value, nil if unset, into
+// an Option.
+func FromWrappersPBUInt64(v *wrapperspb.UInt64Value) goption.Option[uint64] {
+	if v == nil {
+		return goption.None[uint64]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBUInt64 converts o into a wrapperspb.UInt64Value, nil if o is
+// empty.
+func ToWrappersPBUInt64(o goption.Option[uint64]) *wrapperspb.UInt64Value {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.UInt64(v)
+}
+
+// FromWrappersPBInt32 converts a wrapperspb.Int32Value, nil if unset, into
+// an Option.
+func FromWrappersPBInt32(v *wrapperspb.Int32Value) goption.Option[int32] {
+	if v == nil {
+		return goption.None[int32]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBInt32 converts o into a wrapperspb.Int32Value, nil if o is
+// empty.
+func ToWrappersPBInt32(o goption.Option[int32]) *wrapperspb.Int32Value {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Int32(v)
+}
+
+// FromWrappersPBUInt32 converts a wrapperspb.UInt32Value, nil if unset, into
+// an Option.
+func FromWrappersPBUInt32(v *wrapperspb.UInt32Value) goption.Option[uint32] {
+	if v == nil {
+		return goption.None[uint32]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBUInt32 converts o into a wrapperspb.UInt32Value, nil if o is
+// empty.
+func ToWrappersPBUInt32(o goption.Option[uint32]) *wrapperspb.UInt32Value {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.UInt32(v)
+}
+
+// FromWrappersPBBool converts a wrapperspb.BoolValue, nil if unset, into
+// an Option.
+func FromWrappersPBBool(v *wrapperspb.BoolValue) goption.Option[bool] {
+	if v == nil {
+		return goption.None[bool]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBBool converts o into a wrapperspb.BoolValue, nil if o is
+// empty.
+func ToWrappersPBBool(o goption.Option[bool]) *wrapperspb.BoolValue {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Bool(v)
+}
+
+// FromWrappersPBString converts a wrapperspb.StringValue, nil if unset, into
+// an Option.
+func FromWrappersPBString(v *wrapperspb.StringValue) goption.Option[string] {
+	if v == nil {
+		return goption.None[string]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBString converts o into a wrapperspb.StringValue, nil if o is
+// empty.
+func ToWrappersPBString(o goption.Option[string]) *wrapperspb.StringValue {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.String(v)
+}
+
+// FromWrappersPBBytes converts a wrapperspb.BytesValue, nil if unset, into
+// an Option.
+func FromWrappersPBBytes(v *wrapperspb.BytesValue) goption.Option[[]byte] {
+	if v == nil {
+		return goption.None[[]byte]()
+	}
+	return goption.Some(v.GetValue())
+}
+
+// ToWrappersPBBytes converts o into a wrapperspb.BytesValue, nil if o is
+// empty.
+func ToWrappersPBBytes(o goption.Option[[]byte]) *wrapperspb.BytesValue {
+	v, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Bytes(v)
+}
@@ -0,0 +1,51 @@
+package goptionpb
+
+import (
+	"testing"
+
+	"github.com/olachat/goption"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoOptional(t *testing.T) {
+	s := "ada"
+	if o := FromProtoString(&s); o.Unwrap() != "ada" {
+		t.Errorf("Expected ada, got %v", o)
+	}
+	if o := FromProto[int32](nil); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+
+	p := ToProto(goption.Some(int64(3)))
+	if p == nil || *p != 3 {
+		t.Errorf("Expected pointer to 3, got %v", p)
+	}
+	if p := ToProtoString(goption.None[string]()); p != nil {
+		t.Errorf("Expected nil, got %v", *p)
+	}
+}
+
+func TestWrappers(t *testing.T) {
+	if o := FromWrappersPBInt64(wrapperspb.Int64(-2)); o.Unwrap() != -2 {
+		t.Errorf("Expected -2, got %v", o)
+	}
+	if o := FromWrappersPBString(nil); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+	if o := FromWrappersPBBool(wrapperspb.Bool(false)); !o.Ok() || o.Unwrap() {
+		t.Errorf("Expected Some(false), got %v", o)
+	}
+
+	if w := ToWrappersPBInt64(goption.Some(int64(7))); w.GetValue() != 7 {
+		t.Errorf("Expected 7, got %v", w)
+	}
+	if w := ToWrappersPBDouble(goption.None[float64]()); w != nil {
+		t.Errorf("Expected nil, got %v", w)
+	}
+	if w := ToWrappersPBBytes(goption.Some([]byte("x"))); string(w.GetValue()) != "x" {
+		t.Errorf("Expected x, got %v", w)
+	}
+	if o := FromWrappersPBUInt32(ToWrappersPBUInt32(goption.Some(uint32(9)))); o.Unwrap() != 9 {
+		t.Errorf("Expected 9 to round trip, got %v", o)
+	}
+}
@@ -0,0 +1,209 @@
+// Package goptionsqltest checks how goption.Options round trip through a real
+// database and driver, e.g. one started in a container for the test, so a
+// driver and database combination can be verified before rolling it out.
+package goptionsqltest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Dialect describes how to write the round trip queries for a database.
+type Dialect struct {
+	// Placeholder returns the placeholder of the nth argument, counted from
+	// one. Nil means "?".
+	Placeholder func(n int) string
+	// Types maps kinds to the SQL types values are cast to. Kinds without a
+	// type are skipped.
+	Types map[string]string
+}
+
+// The kinds of values which are round tripped.
+const (
+	KindBool    = "bool"
+	KindInt64   = "int64"
+	KindInt32   = "int32"
+	KindFloat64 = "float64"
+	KindString  = "string"
+	KindBytes   = "bytes"
+	KindTime    = "time"
+)
+
+var kinds = []string{KindBool, KindInt64, KindInt32, KindFloat64, KindString, KindBytes, KindTime}
+
+// Postgres is the Dialect of PostgreSQL.
+var Postgres = Dialect{
+	Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	Types: map[string]string{
+		KindBool:    "BOOLEAN",
+		KindInt64:   "BIGINT",
+		KindInt32:   "INTEGER",
+		KindFloat64: "DOUBLE PRECISION",
+		KindString:  "TEXT",
+		KindBytes:   "BYTEA",
+		KindTime:    "TIMESTAMPTZ",
+	},
+}
+
+// MySQL is the Dialect of MySQL 8. Booleans are cast to integers since MySQL
+// has no boolean type.
+var MySQL = Dialect{
+	Types: map[string]string{
+		KindBool:    "SIGNED",
+		KindInt64:   "SIGNED",
+		KindInt32:   "SIGNED",
+		KindFloat64: "DOUBLE",
+		KindString:  "CHAR",
+		KindBytes:   "BINARY",
+		KindTime:    "DATETIME(6)",
+	},
+}
+
+// SQLite is the Dialect of SQLite, which has no time type.
+var SQLite = Dialect{
+	Types: map[string]string{
+		KindBool:    "INTEGER",
+		KindInt64:   "INTEGER",
+		KindInt32:   "INTEGER",
+		KindFloat64: "REAL",
+		KindString:  "TEXT",
+		KindBytes:   "BLOB",
+	},
+}
+
+// Result is the outcome of round tripping one kind of value.
+type Result struct {
+	Kind string
+	// Null reports whether an empty option was round tripped.
+	Null bool
+	// Err is why the round trip failed, or nil if it succeeded.
+	Err error
+}
+
+// sampleTime is the time which is round tripped. It's in UTC with
+// microsecond precision, which most databases keep.
+var sampleTime = time.Date(2024, 2, 29, 12, 34, 56, 123456000, time.UTC)
+
+// Run round trips an empty and a present option of every kind with a type in
+// d through db, by selecting the option cast to its type and scanning the
+// result into an option of the same kind.
+func Run(ctx context.Context, db *sql.DB, d Dialect) []Result {
+	placeholder := "?"
+	if d.Placeholder != nil {
+		placeholder = d.Placeholder(1)
+	}
+
+	var results []Result
+	for _, kind := range kinds {
+		sqlType, ok := d.Types[kind]
+		if !ok {
+			continue
+		}
+
+		query := fmt.Sprintf("SELECT CAST(%s AS %s)", placeholder, sqlType)
+		for _, null := range []bool{true, false} {
+			results = append(results, Result{Kind: kind, Null: null, Err: roundTripKind(ctx, db, query, kind, null)})
+		}
+	}
+
+	return results
+}
+
+func roundTripKind(ctx context.Context, db *sql.DB, query, kind string, null bool) error {
+	switch kind {
+	case KindBool:
+		return roundTrip(ctx, db, query, true, null, eq[bool])
+	case KindInt64:
+		return roundTrip(ctx, db, query, int64(-1<<40), null, eq[int64])
+	case KindInt32:
+		return roundTrip(ctx, db, query, int32(-1<<20), null, eq[int32])
+	case KindFloat64:
+		return roundTrip(ctx, db, query, 1.25, null, eq[float64])
+	case KindString:
+		return roundTrip(ctx, db, query, "option", null, eq[string])
+	case KindBytes:
+		return roundTrip(ctx, db, query, []byte{0, 1, 0xff}, null, bytes.Equal)
+	case KindTime:
+		return roundTrip(ctx, db, query, sampleTime, null, time.Time.Equal)
+	}
+
+	return fmt.Errorf("unknown kind %q", kind)
+}
+
+func eq[T comparable](a, b T) bool {
+	return a == b
+}
+
+// roundTrip selects an option of sample, empty if null, and checks that it
+// scans back into an equal option.
+func roundTrip[T any](ctx context.Context, db *sql.DB, query string, sample T, null bool, equal func(a, b T) bool) error {
+	arg := goption.Some(sample)
+	if null {
+		arg = goption.None[T]()
+	}
+
+	var scanned goption.Option[T]
+	if err := db.QueryRowContext(ctx, query, arg).Scan(&scanned); err != nil {
+		return err
+	}
+
+	if scanned.Ok() != arg.Ok() {
+		return fmt.Errorf("expected presence %v, got %v", arg.Ok(), scanned.Ok())
+	}
+	if v, ok := scanned.Get(); ok && !equal(v, sample) {
+		return fmt.Errorf("expected %v, got %v", sample, v)
+	}
+	return nil
+}
+
+// Table formats results as a compatibility table with one row per kind.
+func Table(results []Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-8s %-6s %s\n", "KIND", "NULL", "VALUE")
+
+	byKind := make(map[string][2]string)
+	var order []string
+	for _, r := range results {
+		cells, seen := byKind[r.Kind]
+		if !seen {
+			order = append(order, r.Kind)
+		}
+		status := "ok"
+		if r.Err != nil {
+			status = "FAIL: " + r.Err.Error()
+		}
+		if r.Null {
+			cells[0] = status
+		} else {
+			cells[1] = status
+		}
+		byKind[r.Kind] = cells
+	}
+
+	for _, kind := range order {
+		cells := byKind[kind]
+		fmt.Fprintf(&b, "%-8s %-6s %s\n", kind, cells[0], cells[1])
+	}
+	return b.String()
+}
+
+// Check runs the round trips of Run and reports every failed one to t, with
+// the compatibility table logged.
+func Check(t testing.TB, db *sql.DB, d Dialect) {
+	t.Helper()
+
+	results := Run(context.Background(), db, d)
+	t.Log("\n" + Table(results))
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("Round trip of %s (null: %v) failed: %s", r.Kind, r.Null, r.Err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package goptionsqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/olachat/goption/convert"
+)
+
+// echoDriver returns the argument of every query as its only row, as text if
+// textual is set.
+type echoDriver struct {
+	textual bool
+}
+
+func (d echoDriver) Open(string) (driver.Conn, error) {
+	return echoConn{d}, nil
+}
+
+func (d echoDriver) Connect(context.Context) (driver.Conn, error) {
+	return echoConn{d}, nil
+}
+
+func (d echoDriver) Driver() driver.Driver {
+	return d
+}
+
+type echoConn struct {
+	d echoDriver
+}
+
+func (c echoConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c echoConn) Close() error {
+	return nil
+}
+
+func (c echoConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c echoConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, "SELECT CAST(") || len(args) != 1 {
+		return nil, errors.New("unexpected query")
+	}
+
+	v := args[0].Value
+	if c.d.textual && v != nil {
+		v = convert.AsString(v)
+	}
+	return &echoRows{value: v}, nil
+}
+
+type echoRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *echoRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *echoRows) Close() error {
+	return nil
+}
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = r.value, true
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	db := sql.OpenDB(echoDriver{})
+	defer db.Close()
+
+	results := Run(context.Background(), db, Postgres)
+	if len(results) != 2*len(kinds) {
+		t.Fatalf("Expected %d results, got %d", 2*len(kinds), len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("Expected %s (null: %v) to round trip, got %s", r.Kind, r.Null, r.Err)
+		}
+	}
+
+	Check(t, db, SQLite)
+}
+
+func TestRunFailures(t *testing.T) {
+	db := sql.OpenDB(echoDriver{textual: true})
+	defer db.Close()
+
+	results := Run(context.Background(), db, MySQL)
+	failed := make(map[string]bool)
+	for _, r := range results {
+		if r.Null && r.Err != nil {
+			t.Errorf("Expected NULL %s to round trip, got %s", r.Kind, r.Err)
+		}
+		if r.Err != nil {
+			failed[r.Kind] = true
+		}
+	}
+	if !failed[KindTime] || failed[KindInt64] {
+		t.Errorf("Expected only textual times to fail, got %v", failed)
+	}
+
+	table := Table(results)
+	if !strings.Contains(table, "int64    ok     ok\n") || !strings.Contains(table, "time     ok     FAIL: ") {
+		t.Errorf("Unexpected table:\n%s", table)
+	}
+}
@@ -0,0 +1,138 @@
+// Package goptiontest provides test suites which verify that a database
+// driver handles goption.Option values correctly, and that custom types
+// round trip through the encodings of goption.Option.
+package goptiontest
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Kind names a category of values covered by the test suite.
+type Kind string
+
+const (
+	KindInt     Kind = "int"
+	KindInt32   Kind = "int32"
+	KindInt64   Kind = "int64"
+	KindUint32  Kind = "uint32"
+	KindFloat64 Kind = "float64"
+	KindBool    Kind = "bool"
+	KindString  Kind = "string"
+	KindBytes   Kind = "bytes"
+	KindTime    Kind = "time"
+)
+
+// Kinds lists every Kind covered by the test suite.
+var Kinds = []Kind{
+	KindInt, KindInt32, KindInt64, KindUint32, KindFloat64,
+	KindBool, KindString, KindBytes, KindTime,
+}
+
+// Driver describes the database under test.
+type Driver struct {
+	// DB is the database under test.
+	DB *sql.DB
+	// Placeholder returns the bind parameter for the n-th argument, starting
+	// at 1. E.g. "$1" for PostgreSQL or "?" for MySQL.
+	Placeholder func(n int) string
+	// ColumnType returns the SQL type values of kind are cast to. An empty
+	// string skips kind.
+	ColumnType func(kind Kind) string
+}
+
+// Postgres returns a Driver for a PostgreSQL database.
+func Postgres(db *sql.DB) Driver {
+	types := map[Kind]string{
+		KindInt:     "bigint",
+		KindInt32:   "integer",
+		KindInt64:   "bigint",
+		KindUint32:  "bigint",
+		KindFloat64: "double precision",
+		KindBool:    "boolean",
+		KindString:  "text",
+		KindBytes:   "bytea",
+		KindTime:    "timestamptz",
+	}
+
+	return Driver{
+		DB:          db,
+		Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		ColumnType:  func(kind Kind) string { return types[kind] },
+	}
+}
+
+// RunScannerTests round trips present and empty options of every Kind
+// through d, reporting each kind as a subtest of t.
+func RunScannerTests(t *testing.T, d Driver) {
+	t.Helper()
+
+	sample := time.Date(2024, 2, 16, 12, 30, 45, 123456000, time.UTC)
+	cases := map[Kind]func(*testing.T, Driver, string){
+		KindInt:     roundTripCase(42, equal[int]),
+		KindInt32:   roundTripCase(int32(-7), equal[int32]),
+		KindInt64:   roundTripCase(int64(1)<<40, equal[int64]),
+		KindUint32:  roundTripCase(uint32(1)<<31, equal[uint32]),
+		KindFloat64: roundTripCase(3.25, equal[float64]),
+		KindBool:    roundTripCase(true, equal[bool]),
+		KindString:  roundTripCase("goption", equal[string]),
+		KindBytes:   roundTripCase([]byte{0, 1, 2, 0xff}, bytes.Equal),
+		KindTime:    roundTripCase(sample, time.Time.Equal),
+	}
+
+	for _, kind := range Kinds {
+		columnType := d.ColumnType(kind)
+		if columnType == "" {
+			continue
+		}
+
+		run := cases[kind]
+		t.Run(string(kind), func(t *testing.T) {
+			run(t, d, columnType)
+		})
+	}
+}
+
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+func roundTripCase[T any](sample T, eq func(T, T) bool) func(*testing.T, Driver, string) {
+	return func(t *testing.T, d Driver, columnType string) {
+		t.Run("some", func(t *testing.T) {
+			got, err := roundTrip(d, columnType, goption.Some(sample))
+			if err != nil {
+				t.Fatalf("Failed round tripping %v: %s", sample, err)
+			}
+			if !got.Ok() {
+				t.Fatalf("Expected %v, got empty option", sample)
+			}
+			if val := got.Unwrap(); !eq(val, sample) {
+				t.Errorf("Expected %v, got %v", sample, val)
+			}
+		})
+
+		t.Run("none", func(t *testing.T) {
+			got, err := roundTrip(d, columnType, goption.None[T]())
+			if err != nil {
+				t.Fatalf("Failed round tripping empty option: %s", err)
+			}
+			if got.Ok() {
+				t.Errorf("Expected empty option, got %v", got)
+			}
+		})
+	}
+}
+
+// roundTrip sends o to the database and scans it back.
+func roundTrip[T any](d Driver, columnType string, o goption.Option[T]) (goption.Option[T], error) {
+	query := fmt.Sprintf("SELECT CAST(%s AS %s)", d.Placeholder(1), columnType)
+	var got goption.Option[T]
+	err := d.DB.QueryRow(query, o).Scan(&got)
+	return got, err
+}
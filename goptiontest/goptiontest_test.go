@@ -0,0 +1,84 @@
+package goptiontest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// echoDriver is a database/sql driver whose queries return their arguments
+// as a single row.
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) {
+	return echoConn{}, nil
+}
+
+type echoConn struct{}
+
+func (echoConn) Prepare(query string) (driver.Stmt, error) {
+	return echoStmt{}, nil
+}
+
+func (echoConn) Close() error {
+	return nil
+}
+
+func (echoConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error {
+	return nil
+}
+
+func (echoStmt) NumInput() int {
+	return -1
+}
+
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *echoRows) Columns() []string {
+	return make([]string, len(r.values))
+}
+
+func (r *echoRows) Close() error {
+	return nil
+}
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func init() {
+	sql.Register("goptiontest-echo", echoDriver{})
+}
+
+func TestRunScannerTests(t *testing.T) {
+	db, err := sql.Open("goptiontest-echo", "")
+	if err != nil {
+		t.Fatalf("Failed opening echo database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	RunScannerTests(t, Postgres(db))
+}
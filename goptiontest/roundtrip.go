@@ -0,0 +1,94 @@
+package goptiontest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// RoundTripJSON asserts that every sample survives being marshaled to JSON
+// and unmarshaled again: the decoded option must be present exactly when the
+// sample is, and must marshal to the same JSON.
+func RoundTripJSON[T any](t testing.TB, samples ...goption.Option[T]) {
+	t.Helper()
+
+	for _, sample := range samples {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			t.Errorf("Failed marshaling %v: %s", sample, err)
+			continue
+		}
+
+		var decoded goption.Option[T]
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("Failed unmarshaling %s: %s", data, err)
+			continue
+		}
+		if decoded.Ok() != sample.Ok() {
+			t.Errorf("Expected presence %v after decoding %s, got %v", sample.Ok(), data, decoded.Ok())
+			continue
+		}
+
+		again, err := json.Marshal(decoded)
+		if err != nil {
+			t.Errorf("Failed marshaling decoded %v: %s", decoded, err)
+			continue
+		}
+		if !bytes.Equal(data, again) {
+			t.Errorf("Expected %s after round trip, got %s", data, again)
+		}
+	}
+}
+
+// RoundTripSQL asserts that every sample survives being converted to a
+// driver.Value and scanned again, without a database: the value must be a
+// valid driver.Value, the scanned option must be present exactly when the
+// sample is, and must convert to an equal value.
+func RoundTripSQL[T any](t testing.TB, samples ...goption.Option[T]) {
+	t.Helper()
+
+	for _, sample := range samples {
+		value, err := sample.Value()
+		if err != nil {
+			t.Errorf("Failed converting %v: %s", sample, err)
+			continue
+		}
+		if value != nil && !driver.IsValue(value) {
+			t.Errorf("Expected a driver.Value for %v, got %T", sample, value)
+			continue
+		}
+
+		var scanned goption.Option[T]
+		if err := scanned.Scan(value); err != nil {
+			t.Errorf("Failed scanning %#v: %s", value, err)
+			continue
+		}
+		if scanned.Ok() != sample.Ok() {
+			t.Errorf("Expected presence %v after scanning %#v, got %v", sample.Ok(), value, scanned.Ok())
+			continue
+		}
+
+		again, err := scanned.Value()
+		if err != nil {
+			t.Errorf("Failed converting scanned %v: %s", scanned, err)
+			continue
+		}
+		if !valuesEqual(value, again) {
+			t.Errorf("Expected %#v after round trip, got %#v", value, again)
+		}
+	}
+}
+
+func valuesEqual(a, b driver.Value) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,76 @@
+package goptiontest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// recorder is a testing.TB recording failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type celsius float64
+
+// lossy rounds to whole degrees when scanned, breaking symmetry.
+type lossy float64
+
+func (l lossy) Value() (driver.Value, error) {
+	return float64(l), nil
+}
+
+func (l *lossy) Scan(src any) error {
+	*l = lossy(int(src.(float64)))
+	return nil
+}
+
+func (l lossy) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprint(float64(l))), nil
+}
+
+func (l *lossy) UnmarshalJSON(data []byte) error {
+	var f float64
+	_, err := fmt.Sscan(string(data), &f)
+	*l = lossy(int(f))
+	return err
+}
+
+func TestRoundTrip(t *testing.T) {
+	RoundTripJSON(t, goption.Some(celsius(21.5)), goption.None[celsius]())
+	RoundTripJSON(t, goption.Some(time.Now()), goption.None[time.Time]())
+	RoundTripSQL(t, goption.Some(celsius(21.5)), goption.None[celsius]())
+	RoundTripSQL(t, goption.Some(time.Now()))
+	RoundTripSQL(t, goption.Some([]byte("bin")))
+}
+
+func TestRoundTripFailures(t *testing.T) {
+	r := &recorder{TB: t}
+	RoundTripJSON(r, goption.Some(lossy(1.5)), goption.Some(lossy(2)))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "after round trip") {
+		t.Errorf("Expected one JSON asymmetry, got %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	RoundTripSQL(r, goption.Some(lossy(1.5)), goption.Some(lossy(2)))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "after round trip") {
+		t.Errorf("Expected one SQL asymmetry, got %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	RoundTripSQL(r, goption.Some(struct{}{}))
+	if len(r.errors) != 1 {
+		t.Errorf("Expected unsupported type to fail, got %v", r.errors)
+	}
+}
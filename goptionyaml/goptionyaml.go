@@ -0,0 +1,123 @@
+// Package goptionyaml decodes YAML with gopkg.in/yaml.v3 such that null
+// values, explicit or tagged !!null, empty the goption.Option fields they're
+// decoded into. yaml.v3 doesn't call unmarshalers for null values and leaves
+// struct fields like Options unchanged, so a null can't clear a prefilled
+// Option by decoding with yaml.v3 alone.
+package goptionyaml
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/olachat/goption"
+	"gopkg.in/yaml.v3"
+)
+
+// Unmarshal decodes data into v like yaml.Unmarshal, then empties the Options
+// whose values in data are null.
+func Unmarshal(data []byte, v any) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		return nil
+	}
+
+	return Decode(&doc, v)
+}
+
+// Decode decodes node into v like node.Decode, then empties the Options whose
+// values in node are null. Use it from UnmarshalYAML methods.
+func Decode(node *yaml.Node, v any) error {
+	if err := node.Decode(v); err != nil {
+		return err
+	}
+
+	clearNulls(node, reflect.ValueOf(v))
+	return nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+}
+
+// clearNulls empties the Options in rv decoded from null values in n.
+func clearNulls(n *yaml.Node, rv reflect.Value) {
+	for n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		n = n.Content[0]
+	}
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	if goption.IsOptionType(rv.Type()) {
+		if isNull(n) && rv.CanSet() {
+			none, _ := goption.NewNone(rv.Type())
+			rv.Set(reflect.ValueOf(none))
+		}
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFields(rv.Type(), nil)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if index, ok := fields[n.Content[i].Value]; ok {
+				clearNulls(n.Content[i+1], rv.FieldByIndex(index))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if n.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range n.Content {
+			if i < rv.Len() {
+				clearNulls(item, rv.Index(i))
+			}
+		}
+	}
+}
+
+// yamlFields returns the indexes of the fields of t by their yaml keys, as
+// yaml.v3 names them: by yaml tags or else by the lowercased field name,
+// with fields tagged inline flattened.
+func yamlFields(t reflect.Type, prefix []int) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int(nil), prefix...), i)
+		name, flags, _ := strings.Cut(tag, ",")
+		if strings.Contains(","+flags+",", ",inline,") {
+			if f.Type.Kind() == reflect.Struct {
+				for key, inner := range yamlFields(f.Type, index) {
+					fields[key] = inner
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = index
+	}
+
+	return fields
+}
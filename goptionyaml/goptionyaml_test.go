@@ -0,0 +1,72 @@
+package goptionyaml
+
+import (
+	"testing"
+
+	"github.com/olachat/goption"
+	"gopkg.in/yaml.v3"
+)
+
+type limits struct {
+	Max goption.Option[int] `yaml:"max"`
+}
+
+type config struct {
+	Host    goption.Option[string] `yaml:"host"`
+	Port    goption.Option[int]
+	Limits  limits  `yaml:",inline"`
+	Nested  *limits `yaml:"nested"`
+	Servers []limits
+	Ignored goption.Option[int] `yaml:"-"`
+}
+
+func prefilled() config {
+	return config{
+		Host:    goption.Some("old"),
+		Port:    goption.Some(1),
+		Limits:  limits{Max: goption.Some(2)},
+		Nested:  &limits{Max: goption.Some(3)},
+		Servers: []limits{{Max: goption.Some(4)}},
+	}
+}
+
+func TestUnmarshalClearsNulls(t *testing.T) {
+	c := prefilled()
+	doc := "host: null\nport: !!null\nmax: ~\nnested:\n  max: null\nservers:\n  - max: null\n"
+	if err := Unmarshal([]byte(doc), &c); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if c.Host.Ok() || c.Port.Ok() || c.Limits.Max.Ok() || c.Nested.Max.Ok() || len(c.Servers) != 1 || c.Servers[0].Max.Ok() {
+		t.Errorf("Expected nulls to empty the options, got %+v", c)
+	}
+
+	// Plain yaml.v3 leaves the prefilled values.
+	c = prefilled()
+	if err := yaml.Unmarshal([]byte(doc), &c); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if !c.Host.Ok() {
+		t.Errorf("Expected yaml.v3 to keep the value, got %+v", c)
+	}
+}
+
+func TestUnmarshalValues(t *testing.T) {
+	c := prefilled()
+	if err := Unmarshal([]byte("host: new\nport: 8080\n"), &c); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if c.Host.Unwrap() != "new" || c.Port.Unwrap() != 8080 || c.Limits.Max.Unwrap() != 2 {
+		t.Errorf("Expected decoded and missing values, got %+v", c)
+	}
+
+	o := goption.Some(1)
+	if err := Unmarshal([]byte("null"), &o); err != nil || o.Ok() {
+		t.Errorf("Expected top level null to empty the option, got %v (%v)", o, err)
+	}
+	if err := Unmarshal([]byte(""), &o); err != nil {
+		t.Errorf("Expected empty document to be ignored, got %v", err)
+	}
+	if err := Unmarshal([]byte("port: x"), &c); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+}
@@ -0,0 +1,56 @@
+package goption
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen for
+// input values. An explicit null makes o empty.
+func (o *Option[T]) UnmarshalGQL(v any) error {
+	if v == nil {
+		*o = None[T]()
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unmarshaling GraphQL input: %w", err)
+	}
+	return o.UnmarshalJSON(data)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen for
+// input values and marks u as set. gqlgen only calls it for input fields
+// which were provided, so fields which weren't stay undefined while an
+// explicit null defines u as empty.
+func (u *Undefinable[T]) UnmarshalGQL(v any) error {
+	var o Option[T]
+	if err := o.UnmarshalGQL(v); err != nil {
+		return err
+	}
+
+	*u = Defined(o)
+	return nil
+}
+
+// DecodeGraphQLInput decodes the arguments of a GraphQL input object, as
+// resolvers receive them in a map[string]any, into dst, which must be a
+// pointer to a struct. Keys are matched to fields by json tags. Undefinable
+// fields whose key isn't in input stay undefined and those whose key is null
+// are defined as empty, so update mutations can tell "not provided" from
+// "clear this field". dst should be a new value, since fields without a key
+// are left unchanged.
+func DecodeGraphQLInput(input map[string]any, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported GraphQL input destination %T, must be a pointer to a struct", dst)
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("decoding GraphQL input: %w", err)
+	}
+	return json.Unmarshal(data, dst)
+}
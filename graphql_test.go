@@ -0,0 +1,75 @@
+package goption
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionUnmarshalGQL(t *testing.T) {
+	o := Some(1)
+	if err := o.UnmarshalGQL(nil); err != nil || o.Ok() {
+		t.Errorf("Expected null to empty the option, got %v (%v)", o, err)
+	}
+	if err := o.UnmarshalGQL(json.Number("42")); err != nil || o.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %v (%v)", o, err)
+	}
+	if err := o.UnmarshalGQL(int64(7)); err != nil || o.Unwrap() != 7 {
+		t.Errorf("Expected 7, got %v (%v)", o, err)
+	}
+	if err := o.UnmarshalGQL("x"); err == nil {
+		t.Errorf("Expected error for mismatched input, got %v", o)
+	}
+
+	var b Option[Bar]
+	if err := b.UnmarshalGQL(map[string]any{"Baz": "nested"}); err != nil || b.Unwrap().Baz != "nested" {
+		t.Errorf("Expected nested input object, got %v (%v)", b, err)
+	}
+}
+
+func TestUndefinableUnmarshalGQL(t *testing.T) {
+	var u Undefinable[string]
+	if err := u.UnmarshalGQL(nil); err != nil || !u.IsSet() || u.Ok() {
+		t.Errorf("Expected explicit null to be defined and empty, got %v (%v)", u, err)
+	}
+	if err := u.UnmarshalGQL("a"); err != nil || u.Option().Unwrap() != "a" {
+		t.Errorf("Expected a, got %v (%v)", u, err)
+	}
+}
+
+type updateUserInput struct {
+	ID    string                `json:"id"`
+	Name  Undefinable[string]   `json:"name"`
+	Email Undefinable[string]   `json:"email"`
+	Age   Option[int]           `json:"age"`
+	Tags  Undefinable[[]string] `json:"tags"`
+}
+
+func TestDecodeGraphQLInput(t *testing.T) {
+	var in updateUserInput
+	err := DecodeGraphQLInput(map[string]any{
+		"id":    "u1",
+		"email": nil,
+		"age":   json.Number("30"),
+		"tags":  []any{"a", "b"},
+	}, &in)
+	if err != nil {
+		t.Fatalf("Failed decoding input: %s", err)
+	}
+
+	if in.ID != "u1" || in.Age.Unwrap() != 30 {
+		t.Errorf("Unexpected plain fields: %+v", in)
+	}
+	if in.Name.IsSet() {
+		t.Errorf("Expected missing name to stay undefined, got %v", in.Name)
+	}
+	if !in.Email.IsSet() || in.Email.Ok() {
+		t.Errorf("Expected null email to be defined and empty, got %v", in.Email)
+	}
+	if tags := in.Tags.Option().UnwrapOrDefault(); len(tags) != 2 || tags[1] != "b" {
+		t.Errorf("Expected tags, got %v", in.Tags)
+	}
+
+	if err := DecodeGraphQLInput(map[string]any{}, in); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
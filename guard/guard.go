@@ -0,0 +1,117 @@
+// Package guard unwraps goption.Options at the top of handlers, returning
+// early when a required value is missing.
+//
+// ReturnIfNone and ReturnIfNoneField stop the calling function when the
+// option is empty. They're meant for functions with a named error result
+// which defer Recover:
+//
+//	func handle(req Request) (err error) {
+//		defer guard.Recover(&err)
+//		name := guard.ReturnIfNoneField(req.Name, "name")
+//		...
+//	}
+//
+// CollectRequired unwraps several options at once without stopping the
+// caller.
+package guard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/olachat/goption"
+)
+
+// ErrMissing is returned for empty options which weren't given a name.
+var ErrMissing = errors.New("required value is missing")
+
+// MissingError names a required value which is missing.
+type MissingError struct {
+	Field string
+}
+
+// Error implements error
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("required field %q is missing", e.Field)
+}
+
+// Is makes MissingErrors match ErrMissing.
+func (e *MissingError) Is(target error) bool {
+	return target == ErrMissing
+}
+
+// stop is the panic value used to return early.
+type stop struct {
+	err error
+}
+
+// ReturnIfNone returns the value of o, or stops the calling function if it's
+// empty, making the error set by Recover ErrMissing.
+func ReturnIfNone[T any](o goption.Option[T]) T {
+	t, ok := o.Get()
+	if !ok {
+		panic(stop{err: ErrMissing})
+	}
+
+	return t
+}
+
+// ReturnIfNoneField is like ReturnIfNone, but the error set by Recover is a
+// *MissingError naming field.
+func ReturnIfNoneField[T any](o goption.Option[T], field string) T {
+	t, ok := o.Get()
+	if !ok {
+		panic(stop{err: &MissingError{Field: field}})
+	}
+
+	return t
+}
+
+// Recover sets *err when ReturnIfNone or ReturnIfNoneField stopped the
+// function deferring it. Other panics are propagated.
+func Recover(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s, ok := r.(stop)
+	if !ok {
+		panic(r)
+	}
+	*err = s.err
+}
+
+// Requirement is a required value given to CollectRequired.
+type Requirement struct {
+	field  string
+	assign func() bool
+}
+
+// Require returns a Requirement storing the value of o into dst, which is
+// named field in errors.
+func Require[T any](dst *T, field string, o goption.Option[T]) Requirement {
+	return Requirement{
+		field: field,
+		assign: func() bool {
+			t, ok := o.Get()
+			if ok {
+				*dst = t
+			}
+			return ok
+		},
+	}
+}
+
+// CollectRequired stores the values of requirements into their destinations
+// and returns a *MissingError naming the first one which is missing. The
+// destinations of the requirements after it are left unchanged.
+func CollectRequired(requirements ...Requirement) error {
+	for _, r := range requirements {
+		if !r.assign() {
+			return &MissingError{Field: r.field}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package guard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/olachat/goption"
+)
+
+func greet(name, title goption.Option[string]) (greeting string, err error) {
+	defer Recover(&err)
+
+	n := ReturnIfNoneField(name, "name")
+	t := ReturnIfNone(title)
+	return t + " " + n, nil
+}
+
+func TestReturnIfNone(t *testing.T) {
+	if g, err := greet(goption.Some("ada"), goption.Some("dr")); err != nil || g != "dr ada" {
+		t.Errorf("Expected greeting, got %q (%v)", g, err)
+	}
+
+	_, err := greet(goption.None[string](), goption.Some("dr"))
+	var missing *MissingError
+	if !errors.As(err, &missing) || missing.Field != "name" || !errors.Is(err, ErrMissing) {
+		t.Errorf("Expected missing name, got %v", err)
+	}
+
+	if _, err := greet(goption.Some("ada"), goption.None[string]()); err != ErrMissing {
+		t.Errorf("Expected ErrMissing, got %v", err)
+	}
+}
+
+func TestRecoverPropagatesPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected panic to propagate, got %v", r)
+		}
+	}()
+
+	func() (err error) {
+		defer Recover(&err)
+		panic("boom")
+	}()
+}
+
+func TestCollectRequired(t *testing.T) {
+	var (
+		name string
+		age  int
+	)
+	err := CollectRequired(
+		Require(&name, "name", goption.Some("ada")),
+		Require(&age, "age", goption.Some(36)),
+	)
+	if err != nil || name != "ada" || age != 36 {
+		t.Errorf("Expected values to be collected, got %q %d (%v)", name, age, err)
+	}
+
+	err = CollectRequired(
+		Require(&name, "name", goption.Some("bob")),
+		Require(&age, "age", goption.None[int]()),
+		Require(&name, "nickname", goption.None[string]()),
+	)
+	var missing *MissingError
+	if !errors.As(err, &missing) || missing.Field != "age" {
+		t.Errorf("Expected missing age, got %v", err)
+	}
+	if name != "bob" || age != 36 {
+		t.Errorf("Expected present values to be stored and missing ones unchanged, got %q %d", name, age)
+	}
+}
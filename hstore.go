@@ -0,0 +1,176 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Hstore is a PostgreSQL hstore value. NULL values are represented by empty
+// options, so NULL hstore columns scan into Option[Hstore].
+type Hstore map[string]Option[string]
+
+// Scan implements sql.Scanner
+func (h *Hstore) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type *Hstore", src)
+	}
+
+	parsed, err := parseHstore(text)
+	if err != nil {
+		return err
+	}
+
+	*h = parsed
+	return nil
+}
+
+// Value implements driver.Valuer
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		writeHstoreString(&sb, k)
+		sb.WriteString("=>")
+		if v, ok := h[k].Get(); ok {
+			writeHstoreString(&sb, v)
+		} else {
+			sb.WriteString("NULL")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func writeHstoreString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(s[i])
+	}
+	sb.WriteByte('"')
+}
+
+// hstoreParser parses the textual hstore representation.
+type hstoreParser struct {
+	text string
+	pos  int
+}
+
+func parseHstore(text string) (Hstore, error) {
+	p := hstoreParser{text: text}
+	h := Hstore{}
+
+	p.skipSpace()
+	for p.pos < len(p.text) {
+		key, quoted, err := p.token()
+		if err != nil {
+			return nil, err
+		}
+		if !quoted && strings.EqualFold(key, "NULL") {
+			return nil, p.errorf("NULL key")
+		}
+
+		p.skipSpace()
+		if !strings.HasPrefix(p.text[p.pos:], "=>") {
+			return nil, p.errorf("expected =>")
+		}
+		p.pos += 2
+		p.skipSpace()
+
+		value, quoted, err := p.token()
+		if err != nil {
+			return nil, err
+		}
+		if !quoted && strings.EqualFold(value, "NULL") {
+			h[key] = None[string]()
+		} else {
+			h[key] = Some(value)
+		}
+
+		p.skipSpace()
+		if p.pos < len(p.text) {
+			if p.text[p.pos] != ',' {
+				return nil, p.errorf("expected ,")
+			}
+			p.pos++
+			p.skipSpace()
+		}
+	}
+
+	return h, nil
+}
+
+func (p *hstoreParser) skipSpace() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// token returns the next key or value and whether it was quoted.
+func (p *hstoreParser) token() (string, bool, error) {
+	if p.pos >= len(p.text) {
+		return "", false, p.errorf("unexpected end")
+	}
+
+	if p.text[p.pos] != '"' {
+		start := p.pos
+		for p.pos < len(p.text) && strings.IndexByte(" \t\r\n,=\"", p.text[p.pos]) < 0 {
+			p.pos++
+		}
+		if p.pos == start {
+			return "", false, p.errorf("expected key or value")
+		}
+		return p.text[start:p.pos], false, nil
+	}
+
+	var sb strings.Builder
+	p.pos++
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.text) {
+				return "", false, p.errorf("unterminated escape")
+			}
+			sb.WriteByte(p.text[p.pos])
+		case '"':
+			p.pos++
+			return sb.String(), true, nil
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+
+	return "", false, p.errorf("unterminated string")
+}
+
+func (p *hstoreParser) errorf(msg string) error {
+	return fmt.Errorf("invalid hstore %q at offset %d: %s", p.text, p.pos, msg)
+}
@@ -0,0 +1,61 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHstoreScan(t *testing.T) {
+	var h Option[Hstore]
+	if err := h.Scan([]byte(`"a"=>"1", "b"=>NULL, "quote \"me\""=>"back\\slash", plain=>word`)); err != nil {
+		t.Fatalf("Failed scanning hstore: %s", err)
+	}
+
+	expected := Hstore{
+		"a":          Some("1"),
+		"b":          None[string](),
+		`quote "me"`: Some(`back\slash`),
+		"plain":      Some("word"),
+	}
+	if !reflect.DeepEqual(h.Unwrap(), expected) {
+		t.Errorf("Expected %v, got %v", expected, h.Unwrap())
+	}
+
+	if err := h.Scan(nil); err != nil || h.Ok() {
+		t.Errorf("Expected empty option for NULL, got %v (%v)", h, err)
+	}
+
+	if err := h.Scan(""); err != nil || len(h.Unwrap()) != 0 {
+		t.Errorf("Expected empty hstore, got %v (%v)", h, err)
+	}
+
+	for _, invalid := range []string{`"a"=>`, `"a" "b"`, `"a"=>"b" "c"=>"d"`, `"a=>"b"`, `NULL=>"b"`} {
+		if err := h.Scan(invalid); err == nil {
+			t.Errorf("Expected error scanning %q", invalid)
+		}
+	}
+}
+
+func TestHstoreValue(t *testing.T) {
+	h := Hstore{
+		"b":   None[string](),
+		"a":   Some(`say "hi"`),
+		`c\d`: Some(""),
+	}
+	val, err := Some(h).Value()
+	if err != nil {
+		t.Fatalf("Failed converting hstore: %s", err)
+	}
+	if val != `"a"=>"say \"hi\"", "b"=>NULL, "c\\d"=>""` {
+		t.Errorf("Unexpected hstore value: %v", val)
+	}
+
+	var scanned Hstore
+	if err := scanned.Scan(val); err != nil || !reflect.DeepEqual(scanned, h) {
+		t.Errorf("Expected round trip, got %v (%v)", scanned, err)
+	}
+
+	if val, err := Hstore(nil).Value(); err != nil || val != nil {
+		t.Errorf("Expected nil hstore to be NULL, got %v (%v)", val, err)
+	}
+}
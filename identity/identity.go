@@ -0,0 +1,120 @@
+// Package identity carries the optional identity of the caller of an HTTP
+// request through its context, and logs it in access logs.
+package identity
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/olachat/goption"
+)
+
+// Identity is who made a request.
+type Identity struct {
+	// Subject identifies the caller, e.g. a user ID.
+	Subject string
+	// Email is the email address of the caller, if known.
+	Email goption.Option[string]
+}
+
+// Extractor returns the identity of the caller of r, or an empty option for
+// anonymous requests.
+type Extractor func(r *http.Request) goption.Option[Identity]
+
+// HeaderExtractor returns an Extractor reading the subject and email from
+// the headers set by an authenticating proxy, e.g. X-Forwarded-User and
+// X-Forwarded-Email. Requests without a subject are anonymous. Only use it
+// behind a proxy which strips these headers from client requests.
+func HeaderExtractor(subjectHeader, emailHeader string) Extractor {
+	return func(r *http.Request) goption.Option[Identity] {
+		subject := r.Header.Get(subjectHeader)
+		if subject == "" {
+			return goption.None[Identity]()
+		}
+
+		id := Identity{Subject: subject}
+		if email := r.Header.Get(emailHeader); email != "" {
+			id.Email = goption.Some(email)
+		}
+		return goption.Some(id)
+	}
+}
+
+type contextKey struct{}
+
+// SetIdentity returns a copy of ctx carrying id.
+func SetIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IdentityFrom returns the identity carried by ctx, if any.
+func IdentityFrom(ctx context.Context) goption.Option[Identity] {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	if !ok {
+		return goption.None[Identity]()
+	}
+
+	return goption.Some(id)
+}
+
+// ExtractIdentity returns the identity stored in the context of r by
+// Middleware, if any.
+func ExtractIdentity(r *http.Request) goption.Option[Identity] {
+	return IdentityFrom(r.Context())
+}
+
+// Middleware stores the identity returned by extract in the context of
+// requests before passing them to next. Anonymous requests are passed on
+// unchanged.
+func Middleware(extract Extractor, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := extract(r).Get(); ok {
+			r = r.WithContext(SetIdentity(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AccessLog logs every request served by next to logger, with the subject
+// of its identity if it has one. Wrap it with Middleware so the identity is
+// available.
+func AccessLog(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", sw.status),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if id, ok := ExtractIdentity(r).Get(); ok {
+			attrs = append(attrs, slog.String("subject", id.Subject))
+		}
+		logger.LogAttrs(r.Context(), slog.LevelInfo, "request", attrs...)
+	})
+}
+
+// statusWriter records the status code written to a ResponseWriter.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the wrapped ResponseWriter, for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
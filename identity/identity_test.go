@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/olachat/goption"
+)
+
+func TestContext(t *testing.T) {
+	if id := IdentityFrom(context.Background()); id.Ok() {
+		t.Errorf("Expected no identity, got %v", id)
+	}
+
+	ctx := SetIdentity(context.Background(), Identity{Subject: "u1"})
+	if id := IdentityFrom(ctx); id.UnwrapOrDefault().Subject != "u1" {
+		t.Errorf("Expected identity u1, got %v", id)
+	}
+}
+
+func TestHeaderExtractor(t *testing.T) {
+	extract := HeaderExtractor("X-Forwarded-User", "X-Forwarded-Email")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if id := extract(r); id.Ok() {
+		t.Errorf("Expected anonymous request, got %v", id)
+	}
+
+	r.Header.Set("X-Forwarded-User", "u1")
+	if id := extract(r); id.Unwrap() != (Identity{Subject: "u1"}) {
+		t.Errorf("Expected identity without email, got %v", id)
+	}
+
+	r.Header.Set("X-Forwarded-Email", "u1@example.com")
+	expected := Identity{Subject: "u1", Email: goption.Some("u1@example.com")}
+	if id := extract(r); id.Unwrap() != expected {
+		t.Errorf("Expected %v, got %v", expected, id)
+	}
+}
+
+func TestMiddlewareAccessLog(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	var seen goption.Option[Identity]
+	handler := Middleware(HeaderExtractor("X-User", "X-Email"), AccessLog(logger,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = ExtractIdentity(r)
+			w.WriteHeader(http.StatusTeapot)
+		})))
+
+	r := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	r.Header.Set("X-User", "u1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if seen.UnwrapOrDefault().Subject != "u1" {
+		t.Errorf("Expected handler to see identity u1, got %v", seen)
+	}
+	if line := logs.String(); !strings.Contains(line, "status=418") || !strings.Contains(line, "subject=u1") {
+		t.Errorf("Unexpected access log: %s", line)
+	}
+
+	logs.Reset()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/brew", nil))
+	if seen.Ok() {
+		t.Errorf("Expected anonymous request, got %v", seen)
+	}
+	if line := logs.String(); strings.Contains(line, "subject=") {
+		t.Errorf("Expected no subject in access log of anonymous request: %s", line)
+	}
+}
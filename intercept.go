@@ -0,0 +1,139 @@
+package goption
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Interceptor transforms the value of column, e.g. to mask or hash personal
+// data. It returns values it doesn't handle unchanged, and nil to make the
+// option empty.
+type Interceptor func(column string, v any) (any, error)
+
+// WithScanInterceptors runs interceptors, in order, on the values of options
+// after they're scanned successfully with ColumnScanner or Scanner. Values
+// must keep their type.
+func WithScanInterceptors(interceptors ...Interceptor) CodecOption {
+	return func(c *Codec) {
+		c.scanInterceptors = append(c.scanInterceptors, interceptors...)
+	}
+}
+
+// WithValueInterceptors runs interceptors, in order, on the values of options
+// before they're converted by Valuer. Values must keep their type.
+func WithValueInterceptors(interceptors ...Interceptor) CodecOption {
+	return func(c *Codec) {
+		c.valueInterceptors = append(c.valueInterceptors, interceptors...)
+	}
+}
+
+// InterceptColumns returns an Interceptor applying f to the values of
+// columns, which are matched case insensitively.
+func InterceptColumns(f func(v any) (any, error), columns ...string) Interceptor {
+	return func(column string, v any) (any, error) {
+		for _, c := range columns {
+			if strings.EqualFold(c, column) {
+				return f(v)
+			}
+		}
+		return v, nil
+	}
+}
+
+// InterceptType returns an Interceptor applying f to values of type T in any
+// column.
+func InterceptType[T any](f func(column string, t T) (T, error)) Interceptor {
+	return func(column string, v any) (any, error) {
+		if t, ok := v.(T); ok {
+			return f(column, t)
+		}
+		return v, nil
+	}
+}
+
+// ColumnScanner is like Scanner, but scan interceptors see column as the
+// column name.
+func (c *Codec) ColumnScanner(column string, dest any) sql.Scanner {
+	return codecTarget{codec: c, dest: dest, column: column}
+}
+
+// Valuer returns v, usually an Option, as a driver.Valuer which runs the
+// value interceptors of c with column as the column name before converting
+// it. Interceptors see the value of Options, and the converted value of
+// anything else.
+func (c *Codec) Valuer(column string, v driver.Valuer) driver.Valuer {
+	return codecValuer{codec: c, column: column, v: v}
+}
+
+type codecValuer struct {
+	codec  *Codec
+	column string
+	v      driver.Valuer
+}
+
+// Value implements driver.Valuer
+func (cv codecValuer) Value() (driver.Value, error) {
+	if cv.codec == nil || len(cv.codec.valueInterceptors) == 0 {
+		return cv.v.Value()
+	}
+
+	// Interceptors work on a copy so that the option isn't changed.
+	rv := reflect.ValueOf(cv.v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+	opt, isOption := asReflectOption(cp)
+	if !isOption {
+		value, err := cv.v.Value()
+		if err != nil {
+			return nil, err
+		}
+		return intercept(cv.codec.valueInterceptors, cv.column, value)
+	}
+
+	if err := interceptOption(cv.codec.valueInterceptors, cv.column, opt); err != nil {
+		return nil, err
+	}
+	return cp.Addr().Interface().(driver.Valuer).Value()
+}
+
+// intercept runs interceptors on v.
+func intercept(interceptors []Interceptor, column string, v any) (any, error) {
+	var err error
+	for _, f := range interceptors {
+		if v, err = f(column, v); err != nil {
+			return nil, fmt.Errorf("intercepting column %q: %w", column, err)
+		}
+	}
+
+	return v, nil
+}
+
+// interceptOption runs interceptors on the value of opt if it's present.
+func interceptOption(interceptors []Interceptor, column string, opt reflectOption) error {
+	val, ok := opt.reflectGet()
+	if !ok {
+		return nil
+	}
+
+	v, err := intercept(interceptors, column, val.Interface())
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		opt.reflectClear()
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(val.Type()) {
+		return fmt.Errorf("intercepting column %q: interceptor returned %s, expected %s", column, rv.Type(), val.Type())
+	}
+	val.Set(rv)
+	return nil
+}
@@ -0,0 +1,96 @@
+package goption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func hashEmail(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:4]), nil
+}
+
+func TestScanInterceptors(t *testing.T) {
+	codec := NewCodec(
+		WithScanInterceptors(
+			InterceptColumns(hashEmail, "email"),
+			InterceptType(func(column string, s string) (string, error) {
+				return strings.TrimSpace(s), nil
+			}),
+		),
+	)
+
+	var email, name Option[string]
+	if err := codec.ColumnScanner("Email", &email).Scan("ada@example.com"); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+	if v, _ := hashEmail("ada@example.com"); email.Unwrap() != v {
+		t.Errorf("Expected hashed email %v, got %q", v, email.Unwrap())
+	}
+	if err := codec.ColumnScanner("name", &name).Scan(" ada "); err != nil || name.Unwrap() != "ada" {
+		t.Errorf("Expected trimmed name, got %q (%v)", name.Unwrap(), err)
+	}
+
+	var missing Option[string]
+	if err := codec.ColumnScanner("email", &missing).Scan(nil); err != nil || missing.Ok() {
+		t.Errorf("Expected NULL to skip interceptors, got %v (%v)", missing, err)
+	}
+}
+
+func TestScanInterceptorErrors(t *testing.T) {
+	redact := NewCodec(WithScanInterceptors(func(string, any) (any, error) { return nil, nil }))
+	o := Some(1)
+	if err := redact.ColumnScanner("id", &o).Scan(int64(2)); err != nil || o.Ok() {
+		t.Errorf("Expected nil to clear option, got %v (%v)", o, err)
+	}
+
+	retype := NewCodec(WithScanInterceptors(func(string, any) (any, error) { return "x", nil }))
+	if err := retype.ColumnScanner("id", &o).Scan(int64(2)); err == nil {
+		t.Errorf("Expected error for interceptor changing type")
+	}
+
+	failure := errors.New("failure")
+	failing := NewCodec(WithScanInterceptors(func(string, any) (any, error) { return nil, failure }))
+	if err := failing.ColumnScanner("id", &o).Scan(int64(2)); !errors.Is(err, failure) {
+		t.Errorf("Expected interceptor error, got %v", err)
+	}
+}
+
+func TestValueInterceptors(t *testing.T) {
+	codec := NewCodec(WithValueInterceptors(InterceptColumns(hashEmail, "email")))
+
+	email := Some("ada@example.com")
+	v, err := codec.Valuer("email", email).Value()
+	if want, _ := hashEmail("ada@example.com"); err != nil || v != want {
+		t.Errorf("Expected hashed email %v, got %v (%v)", want, v, err)
+	}
+	if email.Unwrap() != "ada@example.com" {
+		t.Errorf("Expected option to be left alone, got %q", email.Unwrap())
+	}
+
+	if v, err := codec.Valuer("name", Some("ada")).Value(); err != nil || v != "ada" {
+		t.Errorf("Expected other columns to pass through, got %v (%v)", v, err)
+	}
+	if v, err := codec.Valuer("email", None[string]()).Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, got %v (%v)", v, err)
+	}
+}
+
+func TestValueInterceptorsPointer(t *testing.T) {
+	codec := NewCodec(WithValueInterceptors(InterceptColumns(hashEmail, "email")))
+	email := Some("ada@example.com")
+	v, err := codec.Valuer("email", &email).Value()
+	if want, _ := hashEmail("ada@example.com"); err != nil || v != want {
+		t.Errorf("Expected hashed email %v, got %v (%v)", want, v, err)
+	}
+	if email.Unwrap() != "ada@example.com" {
+		t.Errorf("Expected option to be left alone, got %q", email.Unwrap())
+	}
+}
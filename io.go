@@ -0,0 +1,87 @@
+package goption
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// ErrTooLarge is returned by ReadAllOption when the stream exceeds its limit.
+var ErrTooLarge = errors.New("stream exceeds size limit")
+
+// ReaderOr returns a reader over the bytes of o if it's present, otherwise it
+// returns fallback.
+func ReaderOr(o Option[[]byte], fallback io.Reader) io.Reader {
+	if !o.ok {
+		return fallback
+	}
+
+	return bytes.NewReader(o.t)
+}
+
+// ReadAllOption reads r until EOF. It returns an empty option if r is nil or
+// yields no bytes, e.g. a request without a body. If r yields more than limit
+// bytes it returns ErrTooLarge; a limit below zero disables the check.
+func ReadAllOption(r io.Reader, limit int64) (Option[[]byte], error) {
+	if r == nil {
+		return None[[]byte](), nil
+	}
+
+	if limit >= 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return None[[]byte](), err
+	}
+	if limit >= 0 && int64(len(data)) > limit {
+		return None[[]byte](), fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, limit)
+	}
+	if len(data) == 0 {
+		return None[[]byte](), nil
+	}
+
+	return Some(data), nil
+}
+
+// ReadFileOption reads the file name from fsys. It returns an empty option if
+// the file doesn't exist, and an error if it exists but can't be read.
+func ReadFileOption(fsys fs.FS, name string) (Option[[]byte], error) {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return None[[]byte](), nil
+	}
+	if err != nil {
+		return None[[]byte](), err
+	}
+
+	return Some(data), nil
+}
+
+// ReadFileResult is like ReadFileOption but returns a Result, for chaining.
+func ReadFileResult(fsys fs.FS, name string) Result[Option[[]byte]] {
+	data, err := ReadFileOption(fsys, name)
+	if err != nil {
+		return Err[Option[[]byte]](err)
+	}
+
+	return Ok(data)
+}
+
+// StatOption returns information about the file name in fsys. It returns an
+// empty option if the file doesn't exist, and an error if it can't be
+// inspected.
+func StatOption(fsys fs.FS, name string) (Option[fs.FileInfo], error) {
+	info, err := fs.Stat(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return None[fs.FileInfo](), nil
+	}
+	if err != nil {
+		return None[fs.FileInfo](), err
+	}
+
+	return Some(info), nil
+}
@@ -0,0 +1,79 @@
+package goption
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReaderOr(t *testing.T) {
+	data, _ := io.ReadAll(ReaderOr(Some([]byte("body")), strings.NewReader("fallback")))
+	if string(data) != "body" {
+		t.Errorf("Expected body, got %q", data)
+	}
+
+	data, _ = io.ReadAll(ReaderOr(None[[]byte](), strings.NewReader("fallback")))
+	if string(data) != "fallback" {
+		t.Errorf("Expected fallback, got %q", data)
+	}
+}
+
+func TestReadAllOption(t *testing.T) {
+	if body, err := ReadAllOption(nil, 10); err != nil || body.Ok() {
+		t.Errorf("Expected empty option for nil reader, got %v (%v)", body, err)
+	}
+
+	if body, err := ReadAllOption(strings.NewReader(""), 10); err != nil || body.Ok() {
+		t.Errorf("Expected empty option for empty reader, got %v (%v)", body, err)
+	}
+
+	if body, err := ReadAllOption(strings.NewReader("0123456789"), 10); err != nil || string(body.Unwrap()) != "0123456789" {
+		t.Errorf("Expected full body, got %v (%v)", body, err)
+	}
+
+	if _, err := ReadAllOption(strings.NewReader("0123456789!"), 10); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+
+	if body, err := ReadAllOption(strings.NewReader("unlimited"), -1); err != nil || string(body.Unwrap()) != "unlimited" {
+		t.Errorf("Expected unlimited read, got %v (%v)", body, err)
+	}
+}
+
+func TestReadFileOption(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.json": {Data: []byte(`{}`)},
+		"dir":         {Mode: fs.ModeDir},
+	}
+
+	if data, err := ReadFileOption(fsys, "config.json"); err != nil || string(data.Unwrap()) != `{}` {
+		t.Errorf("Expected file contents, got %v (%v)", data, err)
+	}
+	if data, err := ReadFileOption(fsys, "missing.json"); err != nil || data.Ok() {
+		t.Errorf("Expected empty option for missing file, got %v (%v)", data, err)
+	}
+	if _, err := ReadFileOption(fsys, "dir"); err == nil {
+		t.Errorf("Expected error reading a directory")
+	}
+
+	if r := ReadFileResult(fsys, "missing.json"); !r.IsOk() {
+		t.Errorf("Expected successful result for missing file, got %v", r.Err())
+	}
+	if r := ReadFileResult(fsys, "dir"); r.IsOk() {
+		t.Errorf("Expected failed result reading a directory")
+	}
+}
+
+func TestStatOption(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("abc")}}
+
+	if info, err := StatOption(fsys, "a.txt"); err != nil || info.Unwrap().Size() != 3 {
+		t.Errorf("Expected file info, got %v (%v)", info, err)
+	}
+	if info, err := StatOption(fsys, "b.txt"); err != nil || info.Ok() {
+		t.Errorf("Expected empty option for missing file, got %v (%v)", info, err)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build go1.23
+
+package goption
+
+import "iter"
+
+// Iter returns an iterator yielding the value of o if it's present, and
+// nothing otherwise.
+func (o Option[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if o.ok {
+			yield(o.t)
+		}
+	}
+}
+
+// FromSeq returns the first value yielded by seq, or an empty option if it
+// yields nothing.
+func FromSeq[T any](seq iter.Seq[T]) Option[T] {
+	for t := range seq {
+		return Some(t)
+	}
+
+	return None[T]()
+}
+
+// Collect returns the values of the options yielded by seq, or an empty
+// option as soon as seq yields an empty one.
+func Collect[T any](seq iter.Seq[Option[T]]) Option[[]T] {
+	var values []T
+	for o := range seq {
+		if !o.ok {
+			return None[[]T]()
+		}
+		values = append(values, o.t)
+	}
+
+	return Some(values)
+}
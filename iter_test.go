@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package goption
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIter(t *testing.T) {
+	if values := slices.Collect(Some(1).Iter()); !slices.Equal(values, []int{1}) {
+		t.Errorf("Expected [1], got %v", values)
+	}
+	if values := slices.Collect(None[int]().Iter()); len(values) != 0 {
+		t.Errorf("Expected no values, got %v", values)
+	}
+
+	for v := range Some("a").Iter() {
+		if v != "a" {
+			t.Errorf("Expected a, got %v", v)
+		}
+		break
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	if o := FromSeq(slices.Values([]int{3, 4})); o.Unwrap() != 3 {
+		t.Errorf("Expected 3, got %v", o)
+	}
+	if o := FromSeq(slices.Values([]int{})); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	if o := Collect(slices.Values([]Option[int]{Some(1), Some(2)})); !slices.Equal(o.Unwrap(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", o)
+	}
+	if o := Collect(slices.Values([]Option[int]{Some(1), None[int](), Some(3)})); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+	if o := Collect(slices.Values([]Option[int]{})); !o.Ok() || len(o.Unwrap()) != 0 {
+		t.Errorf("Expected empty slice, got %v", o)
+	}
+}
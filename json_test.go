@@ -2,6 +2,8 @@ package goption
 
 import (
 	"encoding/json"
+	"math"
+	"reflect"
 	"testing"
 )
 
@@ -56,3 +58,106 @@ func TestJSONUnmarshal(t *testing.T) {
 		t.Errorf("Expected optional value to be present.")
 	}
 }
+
+type Order struct {
+	ID       int
+	Customer Option[Customer]
+	Lines    []Option[Line]
+}
+
+type Customer struct {
+	Name    string
+	Email   Option[string]
+	Address Option[Bar]
+}
+
+type Line struct {
+	SKU      string
+	Discount Option[float64]
+}
+
+func TestJSONNestedRoundTrip(t *testing.T) {
+	order := Order{
+		ID: 1,
+		Customer: Some(Customer{
+			Name:    "ada",
+			Address: Some(Bar{Baz: "street"}),
+		}),
+		Lines: []Option[Line]{Some(Line{SKU: "a", Discount: Some(0.5)}), None[Line](), Some(Line{SKU: "b"})},
+	}
+
+	encoded, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+	expected := `{"ID":1,"Customer":{"Name":"ada","Email":null,"Address":{"Baz":"street"}},` +
+		`"Lines":[{"SKU":"a","Discount":0.5},null,{"SKU":"b","Discount":null}]}`
+	if string(encoded) != expected {
+		t.Errorf("Unexpected encoded data: %s", encoded)
+	}
+
+	var decoded Order
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, order) {
+		t.Errorf("Expected %+v, got %+v", order, decoded)
+	}
+}
+
+func TestJSONUnmarshalReset(t *testing.T) {
+	o := Some(Customer{Name: "old", Email: Some("old@example.com")})
+	if err := json.Unmarshal([]byte(`{"Name":"new"}`), &o); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if c := o.Unwrap(); c.Name != "new" || c.Email.Ok() {
+		t.Errorf("Expected previous value not to be merged in, got %+v", c)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &o); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if o.Ok() || o.t.Name != "" {
+		t.Errorf("Expected null to reset the value, got %#v", o)
+	}
+
+	n := Some(1)
+	if err := json.Unmarshal([]byte(`"x"`), &n); err == nil || n.Unwrap() != 1 {
+		t.Errorf("Expected failed unmarshalling to keep the value, got %v (%v)", n, err)
+	}
+}
+
+func checkAppendJSON[T any](t *testing.T, o Option[T]) {
+	t.Helper()
+	expected, expectedErr := json.Marshal(o)
+	got, err := AppendJSON([]byte("prefix:"), o)
+	if (err != nil) != (expectedErr != nil) {
+		t.Errorf("Expected error %v for %#v, got %v", expectedErr, o, err)
+		return
+	}
+	if err == nil && string(got) != "prefix:"+string(expected) {
+		t.Errorf("Expected %s for %#v, got %s", expected, o, got)
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	checkAppendJSON(t, None[int]())
+	checkAppendJSON(t, Some(true))
+	checkAppendJSON(t, Some(-42))
+	checkAppendJSON(t, Some(int8(-8)))
+	checkAppendJSON(t, Some(uint64(1<<63)))
+	checkAppendJSON(t, Some(uint16(7)))
+	for _, f := range []float64{0, 1.5, -3, 1e21, 1e20, 1e-7, 123456789.125, math.MaxFloat64} {
+		checkAppendJSON(t, Some(f))
+	}
+	for _, f := range []float32{0.1, 1e-7, 3.4e38} {
+		checkAppendJSON(t, Some(f))
+	}
+	checkAppendJSON(t, Some(math.NaN()))
+	checkAppendJSON(t, Some(math.Inf(-1)))
+	for _, s := range []string{"", "plain", `quote " and \ slash`, "tab\tnew\nline\x01", "<html>&", "unicode \u2713 \u2028\u2029", "bad \xff utf8"} {
+		checkAppendJSON(t, Some(s))
+	}
+	checkAppendJSON(t, Some(Bar{Baz: "fallback"}))
+	checkAppendJSON(t, Some([]int{1, 2}))
+}
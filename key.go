@@ -0,0 +1,39 @@
+package goption
+
+// Key is a comparable form of Option[T] for use as a map key. Two Keys are
+// equal if both options are empty, or if both are present with equal values.
+// Every empty option has the same Key, the zero Key, regardless of what it
+// was holding.
+type Key[T comparable] struct {
+	t  T
+	ok bool
+}
+
+// KeyOf returns the Key of o.
+func KeyOf[T comparable](o Option[T]) Key[T] {
+	if !o.ok {
+		return Key[T]{}
+	}
+
+	return Key[T]{t: o.t, ok: true}
+}
+
+// NoneKey returns the Key of empty options, which is the zero Key.
+func NoneKey[T comparable]() Key[T] {
+	return Key[T]{}
+}
+
+// Option returns the optional value k was made from.
+func (k Key[T]) Option() Option[T] {
+	return Option[T]{t: k.t, ok: k.ok}
+}
+
+// Ok returns if k is the Key of a present value.
+func (k Key[T]) Ok() bool {
+	return k.ok
+}
+
+// String implements fmt.Stringer
+func (k Key[T]) String() string {
+	return k.Option().String()
+}
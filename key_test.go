@@ -0,0 +1,31 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	stale := Option[int]{t: 5} // an empty option still holding a value
+
+	counts := map[Key[int]]int{}
+	for _, o := range []Option[int]{Some(1), None[int](), Some(1), stale, Some(0)} {
+		counts[KeyOf(o)]++
+	}
+
+	if counts[KeyOf(Some(1))] != 2 {
+		t.Errorf("Expected 2 counts of Some(1), got %d", counts[KeyOf(Some(1))])
+	}
+	if counts[NoneKey[int]()] != 2 {
+		t.Errorf("Expected 2 counts of None, got %d", counts[NoneKey[int]()])
+	}
+	if counts[KeyOf(Some(0))] != 1 {
+		t.Errorf("Expected Some(0) to be distinct from None, got %d", counts[KeyOf(Some(0))])
+	}
+
+	if o := KeyOf(Some("a")).Option(); o.Unwrap() != "a" {
+		t.Errorf("Expected round trip through Key, got %v", o)
+	}
+	if k := KeyOf(None[string]()); k.Ok() || k != (Key[string]{}) || k.String() != "null" {
+		t.Errorf("Expected None key to be the zero Key, got %v", k)
+	}
+}
@@ -0,0 +1,61 @@
+package goption
+
+// Lens focuses on an optional part T of a structure S, so nested updates of
+// immutable values can be composed instead of hand written.
+type Lens[S, T any] struct {
+	// Get returns the focused part of s, if there is one.
+	Get func(s S) Option[T]
+	// Set returns a copy of s whose focused part is t.
+	Set func(s S, t T) S
+}
+
+// Modify returns a copy of s whose focused part is replaced by f applied to
+// it. If there's no focused part s is returned unchanged.
+func (l Lens[S, T]) Modify(s S, f func(T) T) S {
+	t, ok := l.Get(s).Get()
+	if !ok {
+		return s
+	}
+
+	return l.Set(s, f(t))
+}
+
+// SetOption returns a copy of s whose focused part is o's value. If o is
+// empty s is returned unchanged.
+func (l Lens[S, T]) SetOption(s S, o Option[T]) S {
+	if !o.ok {
+		return s
+	}
+
+	return l.Set(s, o.t)
+}
+
+// Compose returns a Lens focusing on the part C of the part B focused on by
+// outer. When outer has no focused part, setting through the composed lens
+// starts from the zero value of B.
+func Compose[A, B, C any](outer Lens[A, B], inner Lens[B, C]) Lens[A, C] {
+	return Lens[A, C]{
+		Get: func(a A) Option[C] {
+			b, ok := outer.Get(a).Get()
+			if !ok {
+				return None[C]()
+			}
+			return inner.Get(b)
+		},
+		Set: func(a A, c C) A {
+			b := outer.Get(a).UnwrapOrDefault()
+			return outer.Set(a, inner.Set(b, c))
+		},
+	}
+}
+
+// FieldLens returns a Lens focusing on an Option field, given functions
+// reading and replacing that field.
+func FieldLens[S, T any](get func(S) Option[T], set func(S, Option[T]) S) Lens[S, T] {
+	return Lens[S, T]{
+		Get: get,
+		Set: func(s S, t T) S {
+			return set(s, Some(t))
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package goption
+
+import (
+	"testing"
+)
+
+type lensTLS struct {
+	CertFile Option[string]
+}
+
+type lensServer struct {
+	Port Option[int]
+	TLS  Option[lensTLS]
+}
+
+type lensConfig struct {
+	Server Option[lensServer]
+}
+
+var (
+	serverLens = FieldLens(
+		func(c lensConfig) Option[lensServer] { return c.Server },
+		func(c lensConfig, s Option[lensServer]) lensConfig { c.Server = s; return c },
+	)
+	tlsLens = FieldLens(
+		func(s lensServer) Option[lensTLS] { return s.TLS },
+		func(s lensServer, t Option[lensTLS]) lensServer { s.TLS = t; return s },
+	)
+	certLens = FieldLens(
+		func(t lensTLS) Option[string] { return t.CertFile },
+		func(t lensTLS, c Option[string]) lensTLS { t.CertFile = c; return t },
+	)
+	portLens = FieldLens(
+		func(s lensServer) Option[int] { return s.Port },
+		func(s lensServer, p Option[int]) lensServer { s.Port = p; return s },
+	)
+)
+
+func TestLensCompose(t *testing.T) {
+	configCert := Compose(Compose(serverLens, tlsLens), certLens)
+
+	var cfg lensConfig
+	if configCert.Get(cfg).Ok() {
+		t.Errorf("Expected no cert in empty config")
+	}
+
+	updated := configCert.Set(cfg, "cert.pem")
+	if cfg.Server.Ok() {
+		t.Errorf("Expected original config to be unchanged")
+	}
+	if cert := configCert.Get(updated); cert.Unwrap() != "cert.pem" {
+		t.Errorf("Expected cert.pem, got %v", cert)
+	}
+
+	configPort := Compose(serverLens, portLens)
+	updated = configPort.Set(updated, 8080)
+	if configCert.Get(updated).Unwrap() != "cert.pem" || configPort.Get(updated).Unwrap() != 8080 {
+		t.Errorf("Expected setting port to keep the cert, got %#v", updated)
+	}
+}
+
+func TestLensModify(t *testing.T) {
+	configPort := Compose(serverLens, portLens)
+	increment := func(p int) int { return p + 1 }
+
+	var cfg lensConfig
+	if modified := configPort.Modify(cfg, increment); modified.Server.Ok() {
+		t.Errorf("Expected modifying a missing part to be a no-op")
+	}
+
+	cfg = configPort.Set(cfg, 80)
+	if port := configPort.Get(configPort.Modify(cfg, increment)); port.Unwrap() != 81 {
+		t.Errorf("Expected 81, got %v", port)
+	}
+
+	if port := configPort.Get(configPort.SetOption(cfg, None[int]())); port.Unwrap() != 80 {
+		t.Errorf("Expected SetOption with empty option to be a no-op, got %v", port)
+	}
+}
@@ -0,0 +1,163 @@
+package goption
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter decides whether a call may proceed.
+type Limiter interface {
+	Allow() bool
+}
+
+// OutcomeRecorder is implemented by Limiters which track the outcome of the
+// calls they allowed, like circuit breakers.
+type OutcomeRecorder interface {
+	Record(success bool)
+}
+
+// Limit wraps f so it returns an empty option when l sheds the call. If l
+// implements OutcomeRecorder it's told whether f returned or panicked; panics
+// are propagated.
+func Limit[T any](l Limiter, f func() T) func() Option[T] {
+	return func() Option[T] {
+		if !l.Allow() {
+			return None[T]()
+		}
+
+		recorder, records := l.(OutcomeRecorder)
+		success := false
+		if records {
+			defer func() { recorder.Record(success) }()
+		}
+
+		t := f()
+		success = true
+		return Some(t)
+	}
+}
+
+// LimitErr is like Limit for functions which may fail. The wrapped function
+// returns an empty option and a nil error when l sheds the call, and an empty
+// option and the error of f when f fails. If l implements OutcomeRecorder,
+// errors and panics of f are recorded as failures.
+func LimitErr[T any](l Limiter, f func() (T, error)) func() (Option[T], error) {
+	return func() (Option[T], error) {
+		if !l.Allow() {
+			return None[T](), nil
+		}
+
+		recorder, records := l.(OutcomeRecorder)
+		success := false
+		if records {
+			defer func() { recorder.Record(success) }()
+		}
+
+		t, err := f()
+		if err != nil {
+			return None[T](), err
+		}
+		success = true
+		return Some(t), nil
+	}
+}
+
+// TokenBucket is a Limiter allowing rate calls per second on average, with
+// bursts of up to burst calls. It's safe for concurrent use.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow implements Limiter
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Breaker is a circuit breaker Limiter. It opens after threshold consecutive
+// failures, shedding every call for cooldown. Then it lets a single trial call
+// through: success closes the breaker, failure opens it again. It's safe for
+// concurrent use.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil Option[time.Time]
+	trial     bool
+	now       func() time.Time
+}
+
+// NewBreaker returns a closed Breaker.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow implements Limiter
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until, open := b.openUntil.Get()
+	if !open {
+		return true
+	}
+	if b.trial || b.now().Before(until) {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+// Record implements OutcomeRecorder
+func (b *Breaker) Record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if success {
+		b.failures = 0
+		b.openUntil = None[time.Time]()
+		return
+	}
+
+	b.failures++
+	if b.openUntil.Ok() || b.failures >= b.threshold {
+		b.openUntil = Some(b.now().Add(b.cooldown))
+	}
+}
@@ -0,0 +1,121 @@
+package goption
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a controllable clock for limiters.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestLimitTokenBucket(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	bucket := NewTokenBucket(1, 2)
+	bucket.now = clock.now
+
+	calls := 0
+	limited := Limit(bucket, func() int {
+		calls++
+		return calls
+	})
+
+	if limited() != Some(1) || limited() != Some(2) {
+		t.Errorf("Expected burst of 2 calls to be allowed")
+	}
+	if limited().Ok() {
+		t.Errorf("Expected call beyond burst to be shed")
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	if limited() != Some(3) {
+		t.Errorf("Expected refilled token to allow a call")
+	}
+	if limited().Ok() || calls != 3 {
+		t.Errorf("Expected shed calls not to run f, got %d calls", calls)
+	}
+}
+
+func TestLimitBreaker(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	breaker := NewBreaker(2, time.Minute)
+	breaker.now = clock.now
+
+	fail := true
+	limited := Limit(breaker, func() string {
+		if fail {
+			panic("boom")
+		}
+		return "ok"
+	})
+	call := func() (o Option[string], panicked bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked = true
+			}
+		}()
+		return limited(), false
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, panicked := call(); !panicked {
+			t.Fatalf("Expected panic to propagate")
+		}
+	}
+	if o, panicked := call(); panicked || o.Ok() {
+		t.Errorf("Expected open breaker to shed the call")
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	if _, panicked := call(); !panicked {
+		t.Errorf("Expected trial call after cooldown")
+	}
+	if o, _ := call(); o.Ok() {
+		t.Errorf("Expected failed trial to reopen the breaker")
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	fail = false
+	if o, _ := call(); o != Some("ok") {
+		t.Errorf("Expected successful trial, got %v", o)
+	}
+	if o, _ := call(); o != Some("ok") {
+		t.Errorf("Expected closed breaker to allow calls, got %v", o)
+	}
+}
+
+func TestLimitErrBreaker(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := NewBreaker(2, time.Minute)
+	breaker.now = clock.now
+
+	failure := errors.New("failure")
+	var err error = failure
+	limited := LimitErr(breaker, func() (int, error) {
+		return 1, err
+	})
+
+	for i := 0; i < 2; i++ {
+		if o, got := limited(); got != failure || o.Ok() {
+			t.Fatalf("Expected the error of f, got %v (%v)", o, got)
+		}
+	}
+	if o, got := limited(); got != nil || o.Ok() {
+		t.Errorf("Expected errors to open the breaker, got %v (%v)", o, got)
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	err = nil
+	if o, got := limited(); got != nil || o != Some(1) {
+		t.Errorf("Expected successful trial, got %v (%v)", o, got)
+	}
+	if o, got := limited(); got != nil || o != Some(1) {
+		t.Errorf("Expected closed breaker to allow calls, got %v (%v)", o, got)
+	}
+}
@@ -0,0 +1,28 @@
+package goption
+
+// LoadOr returns the value of opt if it's present, otherwise it returns the
+// result of load.
+func LoadOr[T any](opt Option[T], load func() (T, error)) (T, error) {
+	if opt.ok {
+		return opt.t, nil
+	}
+
+	return load()
+}
+
+// LoadOrStore returns the value of opt if it's present. Otherwise it calls
+// load and, if load succeeds, stores the loaded value into opt so later
+// calls don't load again.
+func LoadOrStore[T any](opt *Option[T], load func() (T, error)) (T, error) {
+	if opt.ok {
+		return opt.t, nil
+	}
+
+	t, err := load()
+	if err != nil {
+		return t, err
+	}
+
+	*opt = Some(t)
+	return t, nil
+}
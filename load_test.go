@@ -0,0 +1,58 @@
+package goption
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadOr(t *testing.T) {
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		return 7, nil
+	}
+
+	if val, err := LoadOr(Some(3), load); err != nil || val != 3 {
+		t.Errorf("Expected present value 3, got %v (%v)", val, err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected load not to be called for present value")
+	}
+
+	if val, err := LoadOr(None[int](), load); err != nil || val != 7 {
+		t.Errorf("Expected loaded value 7, got %v (%v)", val, err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected load to be called once, got %d", calls)
+	}
+}
+
+func TestLoadOrStore(t *testing.T) {
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		return 7, nil
+	}
+
+	var opt Option[int]
+	for i := 0; i < 2; i++ {
+		if val, err := LoadOrStore(&opt, load); err != nil || val != 7 {
+			t.Errorf("Expected loaded value 7, got %v (%v)", val, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected load to be called once, got %d", calls)
+	}
+	if opt.Unwrap() != 7 {
+		t.Errorf("Expected loaded value to be stored, got %v", opt)
+	}
+
+	failure := errors.New("failed")
+	opt = None[int]()
+	if _, err := LoadOrStore(&opt, func() (int, error) { return 0, failure }); err != failure {
+		t.Errorf("Expected load error, got %v", err)
+	}
+	if opt.Ok() {
+		t.Errorf("Expected failed load not to be stored")
+	}
+}
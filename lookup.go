@@ -0,0 +1,22 @@
+package goption
+
+// GetMap returns the value of k in m, or an empty option if m has no such
+// key.
+func GetMap[K comparable, V any](m map[K]V, k K) Option[V] {
+	v, ok := m[k]
+	if !ok {
+		return None[V]()
+	}
+
+	return Some(v)
+}
+
+// GetSlice returns the element of s at index i, or an empty option if i is
+// out of range.
+func GetSlice[T any](s []T, i int) Option[T] {
+	if i < 0 || i >= len(s) {
+		return None[T]()
+	}
+
+	return Some(s[i])
+}
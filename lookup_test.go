@@ -0,0 +1,36 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestGetMap(t *testing.T) {
+	m := map[string]int{"a": 1, "zero": 0}
+	if o := GetMap(m, "a"); o.Unwrap() != 1 {
+		t.Errorf("Expected 1, got %v", o)
+	}
+	if o := GetMap(m, "zero"); !o.Ok() || o.Unwrap() != 0 {
+		t.Errorf("Expected present zero value, got %v", o)
+	}
+	if o := GetMap(m, "b"); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+	if o := GetMap(map[string]int(nil), "a"); o.Ok() {
+		t.Errorf("Expected empty option for nil map, got %v", o)
+	}
+}
+
+func TestGetSlice(t *testing.T) {
+	s := []string{"a", "b"}
+	if o := GetSlice(s, 1); o.Unwrap() != "b" {
+		t.Errorf("Expected b, got %v", o)
+	}
+	for _, i := range []int{-1, 2} {
+		if o := GetSlice(s, i); o.Ok() {
+			t.Errorf("Expected empty option at %d, got %v", i, o)
+		}
+	}
+	if o := GetSlice([]string(nil), 0); o.Ok() {
+		t.Errorf("Expected empty option for nil slice, got %v", o)
+	}
+}
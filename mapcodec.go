@@ -0,0 +1,317 @@
+package goption
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/olachat/goption/convert"
+)
+
+// DefaultMaxMapDepth is the default nesting limit of EncodeMap and DecodeMap.
+const DefaultMaxMapDepth = 32
+
+// ErrMapDepth is returned when a value is nested deeper than the limit of
+// EncodeMap or DecodeMap.
+var ErrMapDepth = errors.New("maximum map nesting depth exceeded")
+
+// ErrMapCycle is returned by EncodeMap when a value contains itself.
+var ErrMapCycle = errors.New("cycle detected while encoding map")
+
+// MapOption configures EncodeMap and DecodeMap.
+type MapOption func(*mapConfig)
+
+type mapConfig struct {
+	maxDepth int
+}
+
+// WithMaxDepth limits how deeply structs, slices and maps are recursed into.
+func WithMaxDepth(depth int) MapOption {
+	return func(c *mapConfig) {
+		c.maxDepth = depth
+	}
+}
+
+func newMapConfig(opts []MapOption) mapConfig {
+	c := mapConfig{maxDepth: DefaultMaxMapDepth}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// EncodeMap encodes v, a struct or pointer to a struct, into a map keyed by
+// field name. Field names are taken from json tags, fields tagged "-" are
+// skipped and fields of embedded structs are promoted like encoding/json
+// promotes them. Empty options are encoded as nil or omitted when tagged omitempty.
+// Fields tagged omitzero are omitted when they're zero according to IsZero.
+// Nested structs, slices and maps are encoded recursively into
+// map[string]any and []any values.
+func EncodeMap(v any, opts ...MapOption) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported map type %T, must be a struct", v)
+	}
+
+	e := mapEncoder{config: newMapConfig(opts), visiting: make(map[uintptr]struct{})}
+	return e.encodeStruct(addressable(rv), 1)
+}
+
+// DecodeMap decodes m into dst, which must be a pointer to a struct. It's the
+// inverse of EncodeMap: keys are matched to fields like EncodeMap names them,
+// nil values decode into empty options and scalar values are converted like
+// Option.Scan converts them. Fields without a key in m are left unchanged.
+func DecodeMap(m map[string]any, dst any, opts ...MapOption) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported map destination %T, must be a pointer to a struct", dst)
+	}
+
+	d := mapDecoder{config: newMapConfig(opts)}
+	return d.decodeStruct(m, rv.Elem(), 1)
+}
+
+// mapFieldsOf returns the fields of t named like in maps.
+func mapFieldsOf(t reflect.Type) []structField {
+	return cachedFields(t, "json", false)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isPlainStruct reports whether values of t are recursed into as structs.
+func isPlainStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !isOptionType(t)
+}
+
+type mapEncoder struct {
+	config   mapConfig
+	visiting map[uintptr]struct{}
+}
+
+func (e *mapEncoder) encodeStruct(rv reflect.Value, depth int) (map[string]any, error) {
+	if depth > e.config.maxDepth {
+		return nil, ErrMapDepth
+	}
+
+	fields := mapFieldsOf(rv.Type())
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		fv := fieldByIndex(rv, f.index, false)
+		if !fv.IsValid() {
+			continue
+		}
+		if f.omitZero && isZeroValue(fv) {
+			continue
+		}
+		if opt, isOption := asReflectOption(fv); isOption {
+			if _, ok := opt.reflectGet(); !ok && f.omitEmpty {
+				continue
+			}
+		}
+
+		val, err := e.encodeValue(fv, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		m[f.name] = val
+	}
+
+	return m, nil
+}
+
+func (e *mapEncoder) encodeValue(rv reflect.Value, depth int) (any, error) {
+	rv = addressable(rv)
+	if opt, isOption := asReflectOption(rv); isOption {
+		val, ok := opt.reflectGet()
+		if !ok {
+			return nil, nil
+		}
+		return e.encodeValue(val, depth)
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		ptr := rv.Pointer()
+		if _, cycle := e.visiting[ptr]; cycle {
+			return nil, ErrMapCycle
+		}
+		e.visiting[ptr] = struct{}{}
+		defer delete(e.visiting, ptr)
+		return e.encodeValue(rv.Elem(), depth)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return e.encodeValue(rv.Elem(), depth)
+	case reflect.Struct:
+		if isPlainStruct(rv.Type()) {
+			return e.encodeStruct(rv, depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+		if depth >= e.config.maxDepth {
+			return nil, ErrMapDepth
+		}
+		out := make([]any, rv.Len())
+		for i := range out {
+			val, err := e.encodeValue(rv.Index(i), depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = val
+		}
+		return out, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if depth >= e.config.maxDepth {
+			return nil, ErrMapDepth
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			val, err := e.encodeValue(iter.Value(), depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = val
+		}
+		return out, nil
+	}
+
+	return rv.Interface(), nil
+}
+
+type mapDecoder struct {
+	config mapConfig
+}
+
+func (d *mapDecoder) decodeStruct(m map[string]any, rv reflect.Value, depth int) error {
+	if depth > d.config.maxDepth {
+		return ErrMapDepth
+	}
+
+	for _, f := range mapFieldsOf(rv.Type()) {
+		src, ok := m[f.name]
+		if !ok {
+			continue
+		}
+		fv := fieldByIndex(rv, f.index, true)
+		if !fv.IsValid() {
+			return fmt.Errorf("%s: cannot set field behind a nil pointer to an unexported struct", f.name)
+		}
+		if err := d.decodeValue(src, fv, depth); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *mapDecoder) decodeValue(src any, rv reflect.Value, depth int) error {
+	if opt, isOption := asReflectOption(rv); isOption {
+		if src == nil {
+			opt.reflectClear()
+			return nil
+		}
+		return d.decodeValue(src, opt.reflectSet(), depth)
+	}
+
+	if src == nil {
+		rv.SetZero()
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(rv.Type().Elem())
+		if err := d.decodeValue(src, elem.Elem(), depth); err != nil {
+			return err
+		}
+		rv.Set(elem)
+		return nil
+	case reflect.Struct:
+		if m, isMap := src.(map[string]any); isMap && isPlainStruct(rv.Type()) {
+			return d.decodeStruct(m, rv, depth+1)
+		}
+	case reflect.Slice:
+		s, isSlice := src.([]any)
+		if !isSlice {
+			break
+		}
+		if depth >= d.config.maxDepth {
+			return ErrMapDepth
+		}
+		out := reflect.MakeSlice(rv.Type(), len(s), len(s))
+		for i, elem := range s {
+			if err := d.decodeValue(elem, out.Index(i), depth+1); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		rv.Set(out)
+		return nil
+	case reflect.Array:
+		s, isSlice := src.([]any)
+		if !isSlice {
+			break
+		}
+		if len(s) != rv.Len() {
+			return fmt.Errorf("cannot decode %d elements into %s", len(s), rv.Type())
+		}
+		if depth >= d.config.maxDepth {
+			return ErrMapDepth
+		}
+		out := reflect.New(rv.Type()).Elem()
+		for i, elem := range s {
+			if err := d.decodeValue(elem, out.Index(i), depth+1); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		rv.Set(out)
+		return nil
+	case reflect.Map:
+		m, isMap := src.(map[string]any)
+		if !isMap || rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if depth >= d.config.maxDepth {
+			return ErrMapDepth
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), len(m))
+		for key, elem := range m {
+			val := reflect.New(rv.Type().Elem()).Elem()
+			if err := d.decodeValue(elem, val, depth+1); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			out.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), val)
+		}
+		rv.Set(out)
+		return nil
+	case reflect.Interface:
+		if !reflect.TypeOf(src).AssignableTo(rv.Type()) {
+			return fmt.Errorf("cannot decode %T into %s", src, rv.Type())
+		}
+		rv.Set(reflect.ValueOf(src))
+		return nil
+	}
+
+	return convert.Assign(rv.Addr().Interface(), src)
+}
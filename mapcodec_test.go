@@ -0,0 +1,186 @@
+package goption
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mapAuthor struct {
+	Name  Option[string] `json:"name"`
+	Email Option[string] `json:"email,omitempty"`
+}
+
+type mapComment struct {
+	Body   string            `json:"body"`
+	Author Option[mapAuthor] `json:"author"`
+}
+
+type mapPayload struct {
+	ID       int64                     `json:"id"`
+	At       Option[time.Time]         `json:"at"`
+	Author   mapAuthor                 `json:"author"`
+	Comments []mapComment              `json:"comments"`
+	Labels   map[string]Option[string] `json:"labels"`
+	Parent   *mapPayload               `json:"parent"`
+	Secret   string                    `json:"-"`
+}
+
+func TestEncodeMapNested(t *testing.T) {
+	at := time.Date(2024, 2, 16, 0, 0, 0, 0, time.UTC)
+	payload := mapPayload{
+		ID:     1,
+		At:     Some(at),
+		Author: mapAuthor{Name: Some("ola")},
+		Comments: []mapComment{
+			{Body: "hi", Author: Some(mapAuthor{Name: Some("bob"), Email: Some("bob@example.com")})},
+			{Body: "anon"},
+		},
+		Labels: map[string]Option[string]{"a": Some("x"), "b": None[string]()},
+		Secret: "hidden",
+	}
+
+	m, err := EncodeMap(&payload)
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+
+	expected := map[string]any{
+		"id":     int64(1),
+		"at":     at,
+		"author": map[string]any{"name": "ola"},
+		"comments": []any{
+			map[string]any{"body": "hi", "author": map[string]any{"name": "bob", "email": "bob@example.com"}},
+			map[string]any{"body": "anon", "author": nil},
+		},
+		"labels": map[string]any{"a": "x", "b": nil},
+		"parent": nil,
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("Unexpected map:\n%#v\nexpected:\n%#v", m, expected)
+	}
+
+	var decoded mapPayload
+	if err := DecodeMap(m, &decoded); err != nil {
+		t.Fatalf("Failed decoding: %s", err)
+	}
+	payload.Secret = ""
+	if !reflect.DeepEqual(decoded, payload) {
+		t.Errorf("Round trip mismatch:\n%#v\n%#v", decoded, payload)
+	}
+}
+
+func TestDecodeMapConversions(t *testing.T) {
+	var payload mapPayload
+	// Values as produced by encoding/json into map[string]any.
+	err := DecodeMap(map[string]any{
+		"id":       float64(3),
+		"author":   map[string]any{"name": nil, "email": "a@example.com"},
+		"comments": []any{map[string]any{"body": "x"}},
+		"parent":   map[string]any{"id": "4"},
+	}, &payload)
+	if err != nil {
+		t.Fatalf("Failed decoding: %s", err)
+	}
+
+	if payload.ID != 3 || payload.Author.Name.Ok() || payload.Author.Email.Unwrap() != "a@example.com" {
+		t.Errorf("Unexpected payload: %#v", payload)
+	}
+	if len(payload.Comments) != 1 || payload.Comments[0].Body != "x" || payload.Comments[0].Author.Ok() {
+		t.Errorf("Unexpected comments: %#v", payload.Comments)
+	}
+	if payload.Parent == nil || payload.Parent.ID != 4 {
+		t.Errorf("Unexpected parent: %#v", payload.Parent)
+	}
+
+	if err := DecodeMap(map[string]any{"id": "nope"}, &payload); err == nil {
+		t.Errorf("Expected conversion error")
+	}
+}
+
+func TestMapDepthAndCycles(t *testing.T) {
+	deep := &mapPayload{ID: 1, Parent: &mapPayload{ID: 2, Parent: &mapPayload{ID: 3}}}
+	if _, err := EncodeMap(deep, WithMaxDepth(2)); !errors.Is(err, ErrMapDepth) {
+		t.Errorf("Expected depth error, got %v", err)
+	}
+	if _, err := EncodeMap(deep, WithMaxDepth(4)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	cyclic := &mapPayload{ID: 1}
+	cyclic.Parent = cyclic
+	if _, err := EncodeMap(cyclic); !errors.Is(err, ErrMapCycle) {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+
+	nested := map[string]any{"parent": map[string]any{"parent": map[string]any{"id": 1}}}
+	var decoded mapPayload
+	if err := DecodeMap(nested, &decoded, WithMaxDepth(2)); !errors.Is(err, ErrMapDepth) {
+		t.Errorf("Expected depth error decoding, got %v", err)
+	}
+}
+
+type mapAudit struct {
+	CreatedAt Option[string] `json:"created_at"`
+	UpdatedAt Option[string] `json:"updated_at,omitempty"`
+}
+
+type MapOwner struct {
+	OwnerID Option[int] `json:"owner_id"`
+}
+
+type mapDocument struct {
+	mapAudit
+	*MapOwner
+	Title Option[string] `json:"title"`
+}
+
+func TestMapEmbedded(t *testing.T) {
+	m, err := EncodeMap(mapDocument{mapAudit: mapAudit{CreatedAt: Some("yesterday")}, Title: Some("doc")})
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	expected := map[string]any{"created_at": "yesterday", "title": "doc"}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("Expected promoted fields %v, got %v", expected, m)
+	}
+
+	var d mapDocument
+	if err := DecodeMap(map[string]any{"created_at": "today", "owner_id": 4, "title": "x"}, &d); err != nil {
+		t.Fatalf("Failed decoding: %s", err)
+	}
+	if d.CreatedAt.Unwrap() != "today" || d.MapOwner == nil || d.OwnerID.Unwrap() != 4 || d.Title.Unwrap() != "x" {
+		t.Errorf("Unexpected decoded document: %+v %+v", d, d.MapOwner)
+	}
+}
+
+type mapArrays struct {
+	Point [2]int            `json:"point"`
+	Pair  Option[[2]string] `json:"pair"`
+	Err   error             `json:"err"`
+	Extra any               `json:"extra"`
+}
+
+func TestMapArraysAndInterfaces(t *testing.T) {
+	in := mapArrays{Point: [2]int{1, 2}, Pair: Some([2]string{"a", "b"}), Extra: "x"}
+	m, err := EncodeMap(in)
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	var out mapArrays
+	if err := DecodeMap(m, &out); err != nil || !reflect.DeepEqual(in, out) {
+		t.Errorf("Expected %+v to round trip, got %+v (%v)", in, out, err)
+	}
+
+	if err := DecodeMap(map[string]any{"point": []any{1}}, &out); err == nil {
+		t.Errorf("Expected error for the wrong number of array elements")
+	}
+	if err := DecodeMap(map[string]any{"err": "not an error"}, &out); err == nil {
+		t.Errorf("Expected error for a value not implementing the interface")
+	}
+	failure := errors.New("failure")
+	if err := DecodeMap(map[string]any{"err": failure}, &out); err != nil || out.Err != failure {
+		t.Errorf("Expected the error to be decoded, got %v (%v)", out.Err, err)
+	}
+}
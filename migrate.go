@@ -0,0 +1,40 @@
+package goption
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Migrate upgrades a stored Option payload when the type inside changes. It
+// decodes raw as an Option[TOld], converts its value if it's present and
+// encodes the result as an Option[TNew] with the same codec. raw is decoded
+// as the form of AppendBinary if it starts with a presence byte, and as JSON
+// otherwise.
+func Migrate[TOld, TNew any](raw []byte, convert func(TOld) (TNew, error)) ([]byte, error) {
+	binary := len(raw) > 0 && raw[0] <= 1
+
+	var old Option[TOld]
+	var err error
+	if binary {
+		old, err = UnmarshalBinary[TOld](raw)
+	} else {
+		err = json.Unmarshal(raw, &old)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %T to migrate: %w", old, err)
+	}
+
+	var migrated Option[TNew]
+	if v, ok := old.Get(); ok {
+		converted, err := convert(v)
+		if err != nil {
+			return nil, fmt.Errorf("migrating %T: %w", old, err)
+		}
+		migrated = Some(converted)
+	}
+
+	if binary {
+		return migrated.AppendBinary(nil)
+	}
+	return json.Marshal(migrated)
+}
@@ -0,0 +1,57 @@
+package goption
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestMigrateJSON(t *testing.T) {
+	type userV2 struct {
+		First, Last string
+	}
+	split := func(name string) (userV2, error) {
+		for i := range name {
+			if name[i] == ' ' {
+				return userV2{First: name[:i], Last: name[i+1:]}, nil
+			}
+		}
+		return userV2{}, errors.New("no last name")
+	}
+
+	migrated, err := Migrate([]byte(`"Ada Lovelace"`), split)
+	if err != nil || string(migrated) != `{"First":"Ada","Last":"Lovelace"}` {
+		t.Errorf("Expected migrated user, got %s (%v)", migrated, err)
+	}
+	if migrated, err := Migrate([]byte(`null`), split); err != nil || string(migrated) != "null" {
+		t.Errorf("Expected null to stay null, got %s (%v)", migrated, err)
+	}
+	if _, err := Migrate([]byte(`"Ada"`), split); err == nil {
+		t.Errorf("Expected error of convert")
+	}
+	if _, err := Migrate([]byte(`42`), split); err == nil {
+		t.Errorf("Expected error for payload of another type")
+	}
+}
+
+func TestMigrateBinary(t *testing.T) {
+	raw, _ := Some(int32(7)).AppendBinary(nil)
+	widen := func(i int32) (int64, error) { return int64(i) * 1000, nil }
+
+	migrated, err := Migrate(raw, widen)
+	if err != nil {
+		t.Fatalf("Failed migrating: %s", err)
+	}
+	if o, err := UnmarshalBinary[int64](migrated); err != nil || o.Unwrap() != 7000 {
+		t.Errorf("Expected 7000, got %v (%v)", o, err)
+	}
+
+	raw, _ = None[int32]().AppendBinary(nil)
+	if migrated, err := Migrate(raw, widen); err != nil || len(migrated) != 1 || migrated[0] != 0 {
+		t.Errorf("Expected empty binary option, got %v (%v)", migrated, err)
+	}
+
+	if _, err := Migrate(raw, func(i int32) (string, error) { return strconv.Itoa(int(i)), nil }); err == nil {
+		t.Errorf("Expected error for a type without a binary form")
+	}
+}
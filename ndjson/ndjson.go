@@ -0,0 +1,135 @@
+// Package ndjson streams newline delimited JSON records, such as structs of
+// goption.Option fields.
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// LineError is an error decoding a single line.
+type LineError struct {
+	// Line is the 1 based line number.
+	Line int
+	// Data is the content of the line.
+	Data []byte
+	Err  error
+}
+
+// Error implements error
+func (e *LineError) Error() string {
+	return fmt.Sprintf("ndjson: line %d: %s", e.Line, e.Err)
+}
+
+// Unwrap returns the decoding error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads records of type T, one JSON value per line. Blank lines are
+// ignored.
+type Reader[T any] struct {
+	scanner *bufio.Scanner
+	line    int
+	skip    bool
+	record  T
+	err     error
+	skipped []*LineError
+}
+
+// NewReader returns a Reader reading from r.
+func NewReader[T any](r io.Reader) *Reader[T] {
+	return &Reader[T]{scanner: bufio.NewScanner(r)}
+}
+
+// SkipErrors makes the reader skip lines which fail to decode instead of
+// stopping. The errors are collected and returned by Skipped.
+func (r *Reader[T]) SkipErrors() *Reader[T] {
+	r.skip = true
+	return r
+}
+
+// MaxLineSize sets the maximum size of a line, which is 64KiB by default.
+func (r *Reader[T]) MaxLineSize(n int) *Reader[T] {
+	r.scanner.Buffer(make([]byte, 0, min(n, 64*1024)), n)
+	return r
+}
+
+// Next advances to the next record, which is then available through Record.
+// It returns false at the end of the input or after an error.
+func (r *Reader[T]) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record T
+		if err := json.Unmarshal(data, &record); err != nil {
+			lineErr := &LineError{Line: r.line, Data: bytes.Clone(data), Err: err}
+			if r.skip {
+				r.skipped = append(r.skipped, lineErr)
+				continue
+			}
+			r.err = lineErr
+			return false
+		}
+
+		r.record = record
+		return true
+	}
+
+	r.err = r.scanner.Err()
+	return false
+}
+
+// Record returns the current record.
+func (r *Reader[T]) Record() T {
+	return r.record
+}
+
+// Err returns the error which stopped the reader, if any.
+func (r *Reader[T]) Err() error {
+	return r.err
+}
+
+// Skipped returns the errors of lines skipped so far, see SkipErrors.
+func (r *Reader[T]) Skipped() []*LineError {
+	return r.skipped
+}
+
+// Writer writes records of type T, one JSON value per line.
+type Writer[T any] struct {
+	w *bufio.Writer
+}
+
+// NewWriter returns a Writer writing to w. Call Flush when done.
+func NewWriter[T any](w io.Writer) *Writer[T] {
+	return &Writer[T]{w: bufio.NewWriter(w)}
+}
+
+// Write writes record as a single line.
+func (w *Writer[T]) Write(record T) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.w.Write(data); err != nil {
+		return err
+	}
+	return w.w.WriteByte('\n')
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer[T]) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,98 @@
+package ndjson
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/olachat/goption"
+)
+
+type event struct {
+	ID     int                    `json:"id"`
+	UserID goption.Option[int]    `json:"user_id"`
+	Note   goption.Option[string] `json:"note"`
+}
+
+const input = `{"id":1,"user_id":10,"note":null}
+
+{"id":2,"note":"hi"}
+{"id":3,"user_id":"oops"}
+{"id":4,"user_id":null}
+`
+
+func TestReaderStopsOnError(t *testing.T) {
+	r := NewReader[event](strings.NewReader(input))
+
+	var ids []int
+	for r.Next() {
+		ids = append(ids, r.Record().ID)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected records 1 and 2, got %v", ids)
+	}
+
+	var lineErr *LineError
+	if err := r.Err(); !errors.As(err, &lineErr) || lineErr.Line != 4 {
+		t.Errorf("Expected error on line 4, got %v", err)
+	}
+}
+
+func TestReaderSkipErrors(t *testing.T) {
+	r := NewReader[event](strings.NewReader(input)).SkipErrors()
+
+	var records []event
+	for r.Next() {
+		records = append(records, r.Record())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %v", records)
+	}
+	if records[0].UserID.Unwrap() != 10 || records[0].Note.Ok() {
+		t.Errorf("Unexpected first record: %v", records[0])
+	}
+	if records[1].UserID.Ok() || records[1].Note.Unwrap() != "hi" {
+		t.Errorf("Expected missing field to be empty, got %v", records[1])
+	}
+	if records[2].ID != 4 || records[2].UserID.Ok() {
+		t.Errorf("Unexpected last record: %v", records[2])
+	}
+
+	skipped := r.Skipped()
+	if len(skipped) != 1 || skipped[0].Line != 4 || !strings.Contains(string(skipped[0].Data), "oops") {
+		t.Errorf("Expected line 4 to be skipped, got %v", skipped)
+	}
+}
+
+func TestReaderMaxLineSize(t *testing.T) {
+	r := NewReader[event](strings.NewReader(`{"id":1,"note":"` + strings.Repeat("x", 100) + `"}`)).MaxLineSize(32)
+	if r.Next() {
+		t.Errorf("Expected long line to fail")
+	}
+	if r.Err() == nil {
+		t.Errorf("Expected an error for the long line")
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter[event](&buf)
+	w.Write(event{ID: 1, UserID: goption.Some(10)})
+	w.Write(event{ID: 2})
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Failed flushing: %s", err)
+	}
+
+	expected := `{"id":1,"user_id":10,"note":null}
+{"id":2,"user_id":null,"note":null}
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,81 @@
+package goption
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FromNullString returns the value of n as an option, which is empty if n
+// isn't valid.
+func FromNullString(n sql.NullString) Option[string] {
+	if !n.Valid {
+		return None[string]()
+	}
+
+	return Some(n.String)
+}
+
+// ToNullString returns o as a sql.NullString.
+func ToNullString(o Option[string]) sql.NullString {
+	return sql.NullString{String: o.t, Valid: o.ok}
+}
+
+// FromNullInt64 returns the value of n as an option, which is empty if n
+// isn't valid.
+func FromNullInt64(n sql.NullInt64) Option[int64] {
+	if !n.Valid {
+		return None[int64]()
+	}
+
+	return Some(n.Int64)
+}
+
+// ToNullInt64 returns o as a sql.NullInt64.
+func ToNullInt64(o Option[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: o.t, Valid: o.ok}
+}
+
+// FromNullFloat64 returns the value of n as an option, which is empty if n
+// isn't valid.
+func FromNullFloat64(n sql.NullFloat64) Option[float64] {
+	if !n.Valid {
+		return None[float64]()
+	}
+
+	return Some(n.Float64)
+}
+
+// ToNullFloat64 returns o as a sql.NullFloat64.
+func ToNullFloat64(o Option[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: o.t, Valid: o.ok}
+}
+
+// FromNullBool returns the value of n as an option, which is empty if n
+// isn't valid.
+func FromNullBool(n sql.NullBool) Option[bool] {
+	if !n.Valid {
+		return None[bool]()
+	}
+
+	return Some(n.Bool)
+}
+
+// ToNullBool returns o as a sql.NullBool.
+func ToNullBool(o Option[bool]) sql.NullBool {
+	return sql.NullBool{Bool: o.t, Valid: o.ok}
+}
+
+// FromNullTime returns the value of n as an option, which is empty if n
+// isn't valid.
+func FromNullTime(n sql.NullTime) Option[time.Time] {
+	if !n.Valid {
+		return None[time.Time]()
+	}
+
+	return Some(n.Time)
+}
+
+// ToNullTime returns o as a sql.NullTime.
+func ToNullTime(o Option[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: o.t, Valid: o.ok}
+}
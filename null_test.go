@@ -0,0 +1,51 @@
+package goption
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestNullConversions(t *testing.T) {
+	if o := FromNullString(sql.NullString{String: "a", Valid: true}); o.Unwrap() != "a" {
+		t.Errorf("Expected a, got %v", o)
+	}
+	if o := FromNullString(sql.NullString{String: "ignored"}); o.Ok() || o.t != "" {
+		t.Errorf("Expected empty option, got %#v", o)
+	}
+	if n := ToNullString(Some("a")); n != (sql.NullString{String: "a", Valid: true}) {
+		t.Errorf("Expected valid NullString, got %v", n)
+	}
+	if n := ToNullString(None[string]()); n.Valid {
+		t.Errorf("Expected invalid NullString, got %v", n)
+	}
+
+	if o := FromNullInt64(ToNullInt64(Some(int64(7)))); o.Unwrap() != 7 {
+		t.Errorf("Expected 7, got %v", o)
+	}
+	if o := FromNullInt64(ToNullInt64(None[int64]())); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+
+	if o := FromNullFloat64(ToNullFloat64(Some(1.5))); o.Unwrap() != 1.5 {
+		t.Errorf("Expected 1.5, got %v", o)
+	}
+	if o := FromNullFloat64(sql.NullFloat64{}); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+
+	if o := FromNullBool(ToNullBool(Some(false))); !o.Ok() || o.Unwrap() {
+		t.Errorf("Expected false, got %v", o)
+	}
+	if o := FromNullBool(sql.NullBool{}); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+
+	now := time.Now()
+	if o := FromNullTime(ToNullTime(Some(now))); !o.Unwrap().Equal(now) {
+		t.Errorf("Expected %v, got %v", now, o)
+	}
+	if n := ToNullTime(None[time.Time]()); n.Valid {
+		t.Errorf("Expected invalid NullTime, got %v", n)
+	}
+}
@@ -0,0 +1,121 @@
+package goption
+
+import (
+	"sync"
+)
+
+// ObservableOption is an optional value which notifies subscribers whenever
+// it's set or cleared. It's safe for concurrent use.
+type ObservableOption[T any] struct {
+	mu          sync.Mutex
+	opt         Option[T]
+	nextID      int
+	subscribers map[int]func(Option[T])
+	// pending are the notifications not delivered yet, in the order the
+	// values were stored, and delivering is set while a goroutine delivers
+	// them.
+	pending    []notification[T]
+	delivering bool
+}
+
+// notification is a value and the subscribers to notify of it.
+type notification[T any] struct {
+	opt         Option[T]
+	subscribers []func(Option[T])
+}
+
+// NewObservable returns an ObservableOption holding o.
+func NewObservable[T any](o Option[T]) *ObservableOption[T] {
+	return &ObservableOption[T]{opt: o}
+}
+
+// Get returns the current value.
+func (o *ObservableOption[T]) Get() Option[T] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.opt
+}
+
+// Set makes the value present and notifies subscribers.
+func (o *ObservableOption[T]) Set(t T) {
+	o.store(Some(t))
+}
+
+// Clear empties the value and notifies subscribers.
+func (o *ObservableOption[T]) Clear() {
+	o.store(None[T]())
+}
+
+// Subscribe registers f to be called with the new value after every Set and
+// Clear. Values are delivered one at a time, in the order they were set, so
+// the last value a subscriber sees is the current one. Subscribers of a value
+// are called in no particular order, without holding the lock, so they may
+// use the ObservableOption themselves. Set and Clear call the subscribers
+// before returning, unless another call is delivering values, e.g. a
+// subscriber calls Set, in which case that call delivers the new value once
+// it's done with the earlier ones. The returned function removes the
+// subscription.
+func (o *ObservableOption[T]) Subscribe(f func(Option[T])) (unsubscribe func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.subscribers == nil {
+		o.subscribers = make(map[int]func(Option[T]))
+	}
+	id := o.nextID
+	o.nextID++
+	o.subscribers[id] = f
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.subscribers, id)
+	}
+}
+
+func (o *ObservableOption[T]) store(opt Option[T]) {
+	o.enqueue(opt)
+	o.deliver()
+}
+
+// enqueue makes opt the current value and queues its notification.
+func (o *ObservableOption[T]) enqueue(opt Option[T]) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.opt = opt
+	subscribers := make([]func(Option[T]), 0, len(o.subscribers))
+	for _, f := range o.subscribers {
+		subscribers = append(subscribers, f)
+	}
+	o.pending = append(o.pending, notification[T]{opt: opt, subscribers: subscribers})
+}
+
+// deliver notifies subscribers of the pending values in order, unless another
+// call is already delivering them.
+func (o *ObservableOption[T]) deliver() {
+	o.mu.Lock()
+	if o.delivering {
+		o.mu.Unlock()
+		return
+	}
+	o.delivering = true
+	defer func() {
+		o.mu.Lock()
+		o.delivering = false
+		o.mu.Unlock()
+	}()
+
+	for len(o.pending) > 0 {
+		n := o.pending[0]
+		o.pending = o.pending[1:]
+		o.mu.Unlock()
+
+		for _, f := range n.subscribers {
+			f(n.opt)
+		}
+		o.mu.Lock()
+	}
+	o.mu.Unlock()
+}
@@ -0,0 +1,91 @@
+package goption
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestObservableOption(t *testing.T) {
+	obs := NewObservable(None[int]())
+	if obs.Get().Ok() {
+		t.Errorf("Expected initial value to be empty")
+	}
+
+	var seen []Option[int]
+	unsubscribe := obs.Subscribe(func(o Option[int]) {
+		seen = append(seen, o)
+	})
+
+	obs.Set(3)
+	obs.Clear()
+	unsubscribe()
+	obs.Set(4)
+
+	if len(seen) != 2 || seen[0] != Some(3) || seen[1].Ok() {
+		t.Errorf("Unexpected notifications: %v", seen)
+	}
+	if obs.Get().Unwrap() != 4 {
+		t.Errorf("Expected current value 4, got %v", obs.Get())
+	}
+}
+
+func TestObservableOptionZeroValue(t *testing.T) {
+	var obs ObservableOption[string]
+	calls := 0
+	obs.Subscribe(func(Option[string]) { calls++ })
+	obs.Set("on")
+	if calls != 1 || obs.Get().Unwrap() != "on" {
+		t.Errorf("Unexpected state: %d calls, value %v", calls, obs.Get())
+	}
+}
+
+func TestObservableOptionReentrantSubscriber(t *testing.T) {
+	obs := NewObservable(None[int]())
+	var reread []Option[int]
+	obs.Subscribe(func(o Option[int]) {
+		reread = append(reread, obs.Get())
+		if v, ok := o.Get(); ok && v < 3 {
+			obs.Set(v + 1)
+		}
+	})
+
+	obs.Set(1)
+	if len(reread) != 3 || reread[0] != Some(1) || obs.Get() != Some(3) {
+		t.Errorf("Expected subscriber to re-read and set the value, got %v and %v", reread, obs.Get())
+	}
+}
+
+func TestObservableOptionConcurrentOrder(t *testing.T) {
+	for round := 0; round < 20; round++ {
+		obs := NewObservable(None[int]())
+		var mu sync.Mutex
+		var last Option[int]
+		calls := 0
+		obs.Subscribe(func(o Option[int]) {
+			time.Sleep(time.Microsecond)
+			mu.Lock()
+			defer mu.Unlock()
+			last = o
+			calls++
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				obs.Set(i)
+				obs.Clear()
+				obs.Set(i)
+			}(i)
+		}
+		wg.Wait()
+
+		mu.Lock()
+		if last != obs.Get() || calls != 24 {
+			t.Fatalf("Expected %d notifications ending with the current value %v, got %d ending with %v", 24, obs.Get(), calls, last)
+		}
+		mu.Unlock()
+	}
+}
@@ -35,6 +35,25 @@ func (o *Option[T]) ExpectRef(msg string) *T {
 	return &o.t
 }
 
+// GetOrInsert sets o to v if it's empty and returns a reference to its value.
+func (o *Option[T]) GetOrInsert(v T) *T {
+	if !o.ok {
+		o.t, o.ok = v, true
+	}
+
+	return &o.t
+}
+
+// GetOrInsertWith sets o to the result of f if it's empty and returns a
+// reference to its value. f is only called if o is empty.
+func (o *Option[T]) GetOrInsertWith(f func() T) *T {
+	if !o.ok {
+		o.t, o.ok = f(), true
+	}
+
+	return &o.t
+}
+
 // UnwrapOr unwraps the optional if it's present, otherwise it returns default.
 func (o Option[T]) UnwrapOr(def T) T {
 	if !o.ok {
@@ -94,6 +113,84 @@ func Apply[In, Out any](in Option[In], f func(In) Out) Option[Out] {
 	return Some(f(in.t))
 }
 
+// Map applies f to the value of o if it's present. It's the same as Apply.
+func Map[T, U any](o Option[T], f func(T) U) Option[U] {
+	return Apply(o, f)
+}
+
+// FlatMap applies f to the value of o if it's present and returns its
+// result, otherwise it returns an empty option.
+func FlatMap[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+
+	return f(o.t)
+}
+
+// Map applies f to the value of o if it's present. Use the package level Map
+// to transform into another type.
+func (o Option[T]) Map(f func(T) T) Option[T] {
+	return Map(o, f)
+}
+
+// FlatMap applies f to the value of o if it's present. Use the package level
+// FlatMap to transform into another type.
+func (o Option[T]) FlatMap(f func(T) Option[T]) Option[T] {
+	return FlatMap(o, f)
+}
+
+// Filter returns o if its value is present and satisfies pred, otherwise an
+// empty option.
+func (o Option[T]) Filter(pred func(T) bool) Option[T] {
+	if !o.ok || !pred(o.t) {
+		return None[T]()
+	}
+
+	return o
+}
+
+// And returns other if o is present, otherwise an empty option.
+func (o Option[T]) And(other Option[T]) Option[T] {
+	if !o.ok {
+		return None[T]()
+	}
+
+	return other
+}
+
+// Or returns o if its value is present, otherwise other.
+func (o Option[T]) Or(other Option[T]) Option[T] {
+	if o.ok {
+		return o
+	}
+
+	return other
+}
+
+// OrElse returns o if its value is present, otherwise the result of f.
+// f is only called if o is empty.
+func (o Option[T]) OrElse(f func() Option[T]) Option[T] {
+	if o.ok {
+		return o
+	}
+
+	return f()
+}
+
+// Xor returns whichever of o and other is present if exactly one of them is,
+// otherwise an empty option.
+func (o Option[T]) Xor(other Option[T]) Option[T] {
+	switch {
+	case o.ok && !other.ok:
+		return o
+	case !o.ok && other.ok:
+		return other
+	}
+
+	return None[T]()
+}
+
 // Do runs the function f which may panic.
 // If f does not panic Some(f()) is returned.
 // Otherwise none is returned.
@@ -107,3 +204,27 @@ func Do[T any](f func() T) (o Option[T]) {
 	o = Some(f())
 	return
 }
+
+// View is an exported representation of an Option, for serializers and
+// reflection based tools which ignore unexported fields.
+type View[T any] struct {
+	Ok    bool
+	Value T
+}
+
+// View returns the exported representation of o.
+func (o Option[T]) View() View[T] {
+	return View[T]{
+		Ok:    o.ok,
+		Value: o.t,
+	}
+}
+
+// FromView returns the Option represented by v.
+// The value of an empty view is discarded.
+func FromView[T any](v View[T]) Option[T] {
+	if !v.Ok {
+		return None[T]()
+	}
+	return Some(v.Value)
+}
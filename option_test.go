@@ -78,6 +78,30 @@ func TestExpectRefMessage(t *testing.T) {
 	None[struct{}]().Expect("my custom message")
 }
 
+// TestGetOrInsert tests that values are inserted into empty options and
+// that the returned pointer refers to the contained value.
+func TestGetOrInsert(t *testing.T) {
+	var o Option[int]
+	p := o.GetOrInsert(1)
+	if *p != 1 || o.Unwrap() != 1 {
+		t.Errorf("Expected 1 to be inserted, got %v", o)
+	}
+	*p = 2
+	if o.Unwrap() != 2 {
+		t.Errorf("Expected reference to the contained value, got %v", o)
+	}
+	if p := o.GetOrInsert(3); *p != 2 {
+		t.Errorf("Expected present value to be kept, got %v", *p)
+	}
+
+	var lazy Option[map[string]int]
+	(*lazy.GetOrInsertWith(func() map[string]int { return map[string]int{} }))["a"]++
+	(*lazy.GetOrInsertWith(func() map[string]int { panic("called") }))["a"]++
+	if lazy.Unwrap()["a"] != 2 {
+		t.Errorf("Expected lazily initialized map, got %v", lazy)
+	}
+}
+
 // TestUnwrapOr tests that or values are returned when unwrap or-ing none.
 // Otherwise it expects the underlying optional value.
 func TestUnwrapOr(t *testing.T) {
@@ -149,6 +173,100 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestMap(t *testing.T) {
+	length := Map(Some("four"), func(s string) int { return len(s) })
+	if length.Unwrap() != 4 {
+		t.Errorf("Expected 4, got %v", length)
+	}
+
+	if Map(None[string](), func(s string) int { return len(s) }).Ok() {
+		t.Errorf("Expected empty optional")
+	}
+
+	double := Some(2).Map(func(i int) int { return i * 2 })
+	if double.Unwrap() != 4 {
+		t.Errorf("Expected 4, got %v", double)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	positive := func(i int) Option[uint] {
+		if i <= 0 {
+			return None[uint]()
+		}
+		return Some(uint(i))
+	}
+
+	if v := FlatMap(Some(3), positive); v.Unwrap() != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+	if v := FlatMap(Some(-3), positive); v.Ok() {
+		t.Errorf("Expected empty optional, got %v", v)
+	}
+	if v := FlatMap(None[int](), positive); v.Ok() {
+		t.Errorf("Expected empty optional, got %v", v)
+	}
+
+	half := func(i int) Option[int] {
+		if i%2 != 0 {
+			return None[int]()
+		}
+		return Some(i / 2)
+	}
+	if v := Some(8).FlatMap(half).FlatMap(half); v.Unwrap() != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if v := Some(6).FlatMap(half).FlatMap(half); v.Ok() {
+		t.Errorf("Expected empty optional, got %v", v)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+
+	if v := Some(4).Filter(even); v.Unwrap() != 4 {
+		t.Errorf("Expected 4, got %v", v)
+	}
+	if v := Some(3).Filter(even); v.Ok() {
+		t.Errorf("Expected empty optional, got %v", v)
+	}
+	if v := None[int]().Filter(func(int) bool { panic("called") }); v.Ok() {
+		t.Errorf("Expected empty optional, got %v", v)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	some1, some2, none := Some(1), Some(2), None[int]()
+
+	for _, tc := range []struct {
+		name     string
+		got      Option[int]
+		expected Option[int]
+	}{
+		{"some.And(some)", some1.And(some2), some2},
+		{"some.And(none)", some1.And(none), none},
+		{"none.And(some)", none.And(some2), none},
+		{"some.Or(some)", some1.Or(some2), some1},
+		{"none.Or(some)", none.Or(some2), some2},
+		{"none.Or(none)", none.Or(none), none},
+		{"some.Xor(some)", some1.Xor(some2), none},
+		{"some.Xor(none)", some1.Xor(none), some1},
+		{"none.Xor(some)", none.Xor(some2), some2},
+		{"none.Xor(none)", none.Xor(none), none},
+	} {
+		if tc.got != tc.expected {
+			t.Errorf("Expected %s to be %v, got %v", tc.name, tc.expected, tc.got)
+		}
+	}
+
+	if v := some1.OrElse(func() Option[int] { panic("called") }); v != some1 {
+		t.Errorf("Expected %v, got %v", some1, v)
+	}
+	if v := none.OrElse(func() Option[int] { return some2 }); v != some2 {
+		t.Errorf("Expected %v, got %v", some2, v)
+	}
+}
+
 func TestDo(t *testing.T) {
 	val := Do(func() int {
 		return 1
@@ -197,3 +315,23 @@ func TestFromRef(t *testing.T) {
 		t.Errorf("FromRef must contain dereferenced value, expected 10 but got %d", unwrapped)
 	}
 }
+
+func TestView(t *testing.T) {
+	view := Some(3).View()
+	if !view.Ok || view.Value != 3 {
+		t.Errorf("Unexpected view of present option: %+v", view)
+	}
+
+	view = None[int]().View()
+	if view.Ok || view.Value != 0 {
+		t.Errorf("Unexpected view of empty option: %+v", view)
+	}
+
+	if opt := FromView(View[int]{Ok: true, Value: 4}); opt.Unwrap() != 4 {
+		t.Errorf("Expected 4, got %v", opt)
+	}
+
+	if opt := FromView(View[int]{Ok: false, Value: 4}); opt != None[int]() {
+		t.Errorf("Expected empty option, got %#v", opt)
+	}
+}
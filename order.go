@@ -0,0 +1,88 @@
+package goption
+
+import (
+	"cmp"
+	"slices"
+)
+
+// NullOrder describes where empty options are placed when sorting.
+//
+// Databases differ in their defaults: PostgreSQL and Oracle place NULLs last
+// in ascending order and first in descending order, MySQL and SQLite do the
+// opposite.
+type NullOrder int
+
+const (
+	// NullsLast places empty options after present ones.
+	NullsLast NullOrder = iota
+	// NullsFirst places empty options before present ones.
+	NullsFirst
+)
+
+// CompareFunc compares two optional values using compare for present values
+// and nulls to place empty ones. It returns a negative number when a sorts
+// before b, a positive number when a sorts after b and zero otherwise.
+func CompareFunc[T any](a, b Option[T], compare func(T, T) int, nulls NullOrder) int {
+	switch {
+	case !a.ok && !b.ok:
+		return 0
+	case !a.ok:
+		if nulls == NullsFirst {
+			return -1
+		}
+		return 1
+	case !b.ok:
+		if nulls == NullsFirst {
+			return 1
+		}
+		return -1
+	}
+
+	return compare(a.t, b.t)
+}
+
+// Compare is CompareFunc using the natural order of T.
+func Compare[T cmp.Ordered](a, b Option[T], nulls NullOrder) int {
+	return CompareFunc(a, b, cmp.Compare[T], nulls)
+}
+
+// SortKey compares two S by a single key.
+type SortKey[S any] func(a, b S) int
+
+// By returns a SortKey ordering by the optional value returned by key, using
+// compare for present values. Custom compare functions allow matching a
+// database's collation.
+func By[S, T any](key func(S) Option[T], compare func(T, T) int, nulls NullOrder) SortKey[S] {
+	return func(a, b S) int {
+		return CompareFunc(key(a), key(b), compare, nulls)
+	}
+}
+
+// Asc returns a SortKey ordering by key in ascending order.
+func Asc[S any, T cmp.Ordered](key func(S) Option[T], nulls NullOrder) SortKey[S] {
+	return By(key, cmp.Compare[T], nulls)
+}
+
+// Desc returns a SortKey ordering by key in descending order. The placement
+// of empty options is given by nulls and is not reversed.
+func Desc[S any, T cmp.Ordered](key func(S) Option[T], nulls NullOrder) SortKey[S] {
+	return By(key, func(a, b T) int { return cmp.Compare(b, a) }, nulls)
+}
+
+// OrderBy combines keys into a single comparison function, like an ORDER BY
+// clause: later keys only break ties of earlier ones.
+func OrderBy[S any](keys ...SortKey[S]) func(a, b S) int {
+	return func(a, b S) int {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// SortBy stably sorts s by keys.
+func SortBy[S any](s []S, keys ...SortKey[S]) {
+	slices.SortStableFunc(s, OrderBy(keys...))
+}
@@ -0,0 +1,70 @@
+package goption
+
+import (
+	"strings"
+	"testing"
+)
+
+type orderRow struct {
+	ID    int
+	Group Option[string]
+	Score Option[int]
+}
+
+func TestCompare(t *testing.T) {
+	if c := Compare(Some(1), Some(2), NullsLast); c >= 0 {
+		t.Errorf("Expected 1 < 2, got %d", c)
+	}
+	if c := Compare(None[int](), Some(2), NullsLast); c <= 0 {
+		t.Errorf("Expected None after Some with NullsLast, got %d", c)
+	}
+	if c := Compare(None[int](), Some(2), NullsFirst); c >= 0 {
+		t.Errorf("Expected None before Some with NullsFirst, got %d", c)
+	}
+	if c := Compare(None[int](), None[int](), NullsFirst); c != 0 {
+		t.Errorf("Expected empty options to be equal, got %d", c)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	rows := []orderRow{
+		{ID: 1, Group: Some("b"), Score: Some(1)},
+		{ID: 2, Group: None[string](), Score: Some(5)},
+		{ID: 3, Group: Some("a"), Score: None[int]()},
+		{ID: 4, Group: Some("a"), Score: Some(3)},
+		{ID: 5, Group: Some("b"), Score: Some(1)},
+	}
+
+	// ORDER BY group ASC NULLS LAST, score DESC NULLS FIRST
+	SortBy(rows,
+		Asc(func(r orderRow) Option[string] { return r.Group }, NullsLast),
+		Desc(func(r orderRow) Option[int] { return r.Score }, NullsFirst),
+	)
+
+	expected := []int{3, 4, 1, 5, 2}
+	for i, r := range rows {
+		if r.ID != expected[i] {
+			t.Errorf("Expected id %d at %d, got %d", expected[i], i, r.ID)
+		}
+	}
+}
+
+func TestByCustomCollation(t *testing.T) {
+	rows := []orderRow{
+		{ID: 1, Group: Some("b")},
+		{ID: 2, Group: Some("A")},
+		{ID: 3, Group: None[string]()},
+	}
+
+	caseInsensitive := func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	SortBy(rows, By(func(r orderRow) Option[string] { return r.Group }, caseInsensitive, NullsFirst))
+
+	expected := []int{3, 2, 1}
+	for i, r := range rows {
+		if r.ID != expected[i] {
+			t.Errorf("Expected id %d at %d, got %d", expected[i], i, r.ID)
+		}
+	}
+}
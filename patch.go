@@ -0,0 +1,108 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyPatch copies the fields of patch, a struct or pointer to a struct,
+// onto the fields of the same name of dst, a pointer to a struct, for
+// PATCH-style partial updates. Fields are named by patch tags or else by
+// field name, and fields of embedded structs are promoted.
+//
+// Like UpdateOptimistic, empty Option fields and undefined Undefinable
+// fields of patch are skipped and other fields are copied. A present value
+// is stored into a dst field of its type, an Option of it or a pointer to
+// it. An Undefinable defined as empty clears an Option or pointer field of
+// dst and zeroes any other field.
+func ApplyPatch(dst any, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported patch destination %T, must be a pointer to a struct", dst)
+	}
+	dv = dv.Elem()
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported patch %T, must be a struct", patch)
+	}
+	pv = addressable(pv)
+
+	dstFields := fieldsByName(dv.Type())
+	for _, f := range cachedFields(pv.Type(), "patch", false) {
+		df, ok := dstFields[f.name]
+		if !ok {
+			return fmt.Errorf("%s has no field %s of the patch", dv.Type(), f.name)
+		}
+		fv := fieldByIndex(pv, f.index, false)
+		if !fv.IsValid() {
+			continue
+		}
+		val, present, set := patchValue(fv)
+		if !set {
+			continue
+		}
+
+		target := fieldByIndex(dv, df.index, true)
+		if !target.IsValid() {
+			return fmt.Errorf("cannot set field %s behind a nil pointer to an unexported struct", f.name)
+		}
+		if err := setPatched(target, val, present); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldsByName returns the fields of t by their names for ApplyPatch.
+func fieldsByName(t reflect.Type) map[string]structField {
+	fields := make(map[string]structField)
+	for _, f := range cachedFields(t, "patch", false) {
+		fields[f.name] = f
+	}
+	return fields
+}
+
+// patchValue returns the value the field fv of a patch holds, whether it's
+// present, and whether the field is set at all.
+func patchValue(fv reflect.Value) (reflect.Value, bool, bool) {
+	arg, set := patchArg(fv)
+	if !set {
+		return reflect.Value{}, false, false
+	}
+
+	av := addressable(reflect.ValueOf(arg))
+	if opt, isOption := asReflectOption(av); isOption {
+		val, ok := opt.reflectGet()
+		return val, ok, true
+	}
+	return av, true, true
+}
+
+// setPatched stores val into target, or clears target if val isn't present.
+func setPatched(target, val reflect.Value, present bool) error {
+	opt, isOption := asReflectOption(target)
+	if !present {
+		if isOption {
+			opt.reflectClear()
+		} else {
+			target.SetZero()
+		}
+		return nil
+	}
+
+	switch {
+	case val.Type().AssignableTo(target.Type()):
+		target.Set(val)
+	case isOption && val.Type().AssignableTo(opt.reflectType()):
+		opt.reflectSet().Set(val)
+	case target.Kind() == reflect.Pointer && val.Type().AssignableTo(target.Type().Elem()):
+		p := reflect.New(target.Type().Elem())
+		p.Elem().Set(val)
+		target.Set(p)
+	default:
+		return fmt.Errorf("cannot store %s into %s", val.Type(), target.Type())
+	}
+
+	return nil
+}
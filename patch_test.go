@@ -0,0 +1,68 @@
+package goption
+
+import (
+	"testing"
+)
+
+type patchedUser struct {
+	Name     string
+	Age      int
+	Email    Option[string]
+	Nickname *string
+	Bio      string
+}
+
+type userUpdate struct {
+	Name     Option[string]
+	Age      Option[int]
+	Email    Undefinable[string]
+	Nickname Option[string]
+	About    Undefinable[string] `patch:"Bio"`
+}
+
+func TestApplyPatch(t *testing.T) {
+	u := patchedUser{Name: "ada", Age: 36, Email: Some("ada@example.com"), Bio: "mathematician"}
+	patch := userUpdate{
+		Age:      Some(37),
+		Email:    Defined(None[string]()),
+		Nickname: Some("countess"),
+	}
+
+	if err := ApplyPatch(&u, patch); err != nil {
+		t.Fatalf("Failed patching: %s", err)
+	}
+	if u.Name != "ada" || u.Age != 37 || u.Email.Ok() || u.Nickname == nil || *u.Nickname != "countess" || u.Bio != "mathematician" {
+		t.Errorf("Unexpected patched user: %+v", u)
+	}
+
+	if err := ApplyPatch(&u, &userUpdate{About: Defined(Some("poet")), Email: Defined(Some("a@b.c"))}); err != nil {
+		t.Fatalf("Failed patching: %s", err)
+	}
+	if u.Bio != "poet" || u.Email.Unwrap() != "a@b.c" {
+		t.Errorf("Unexpected patched user: %+v", u)
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	var u patchedUser
+	if err := ApplyPatch(u, userUpdate{}); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+	if err := ApplyPatch(&u, 1); err == nil {
+		t.Errorf("Expected error for non-struct patch")
+	}
+
+	var unknown struct {
+		Phone Option[string]
+	}
+	if err := ApplyPatch(&u, unknown); err == nil {
+		t.Errorf("Expected error for field missing from destination")
+	}
+
+	mismatched := struct {
+		Age Option[string]
+	}{Age: Some("old")}
+	if err := ApplyPatch(&u, mismatched); err == nil {
+		t.Errorf("Expected error for mismatched types")
+	}
+}
@@ -0,0 +1,257 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Money is an amount of a PostgreSQL money column in minor units, e.g. cents.
+// It assumes two fractional digits, the default of most locales.
+type Money int64
+
+// ParseMoney parses the textual money representations produced by
+// PostgreSQL, e.g. "$1,234.56", "-$0.05", "($1.00)" or "1234.5".
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative, text = true, text[1:len(text)-1]
+	}
+
+	var digits, fraction strings.Builder
+	seenPoint, seenDigit := false, false
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			seenDigit = true
+			if seenPoint {
+				fraction.WriteRune(r)
+			} else {
+				digits.WriteRune(r)
+			}
+		case r == '.':
+			if seenPoint {
+				return 0, fmt.Errorf("invalid money %q", s)
+			}
+			seenPoint = true
+		case r == '-':
+			if seenDigit || negative {
+				return 0, fmt.Errorf("invalid money %q", s)
+			}
+			negative = true
+		case r == ',' || r == ' ' || r == '+':
+			if seenPoint {
+				return 0, fmt.Errorf("invalid money %q", s)
+			}
+		default:
+			// Currency symbols.
+			if seenDigit {
+				return 0, fmt.Errorf("invalid money %q", s)
+			}
+		}
+	}
+
+	if !seenDigit || fraction.Len() > 2 {
+		return 0, fmt.Errorf("invalid money %q", s)
+	}
+
+	minor := digits.String() + (fraction.String() + "00")[:2]
+	amount, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money %q: %w", s, err)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money(amount), nil
+}
+
+// String returns m as a decimal number, e.g. "-1234.56".
+func (m Money) String() string {
+	sign := ""
+	minor := int64(m)
+	if minor < 0 {
+		sign = "-"
+	}
+	units, cents := minor/100, minor%100
+	if units < 0 {
+		units = -units
+	}
+	if cents < 0 {
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, units, cents)
+}
+
+// Scan implements sql.Scanner. Integers are taken as minor units.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*m = Money(v)
+		return nil
+	case string:
+		parsed, err := ParseMoney(v)
+		*m = parsed
+		return err
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		*m = parsed
+		return err
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type *Money", src)
+}
+
+// Value implements driver.Valuer
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Interval is a PostgreSQL interval of fixed length. Intervals with years or
+// months can't be represented as they vary in length.
+type Interval time.Duration
+
+var intervalUnits = map[string]time.Duration{
+	"microsecond": time.Microsecond,
+	"millisecond": time.Millisecond,
+	"second":      time.Second,
+	"sec":         time.Second,
+	"minute":      time.Minute,
+	"min":         time.Minute,
+	"hour":        time.Hour,
+	"day":         24 * time.Hour,
+	"week":        7 * 24 * time.Hour,
+}
+
+// ParseInterval parses the textual interval representations produced by
+// PostgreSQL, e.g. "1 day 02:03:04.5", "-3 days +01:00:00" or
+// "@ 1 day 2 hours ago".
+func ParseInterval(s string) (Interval, error) {
+	fields := strings.Fields(s)
+	if len(fields) > 0 && fields[0] == "@" {
+		fields = fields[1:]
+	}
+
+	ago := false
+	if len(fields) > 0 && fields[len(fields)-1] == "ago" {
+		ago, fields = true, fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid interval %q", s)
+	}
+
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			d, err := parseIntervalClock(field)
+			if err != nil {
+				return 0, fmt.Errorf("invalid interval %q: %w", s, err)
+			}
+			total += d
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("invalid interval %q: missing unit after %s", s, field)
+		}
+		amount, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q: %w", s, err)
+		}
+		i++
+		unit, ok := intervalUnits[strings.TrimSuffix(strings.ToLower(fields[i]), "s")]
+		if !ok {
+			return 0, fmt.Errorf("invalid interval %q: unsupported unit %s", s, fields[i])
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+
+	if ago {
+		total = -total
+	}
+	return Interval(total), nil
+}
+
+// parseIntervalClock parses "[+-]HH:MM[:SS[.ffffff]]".
+func parseIntervalClock(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	hours, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return 0, err
+	}
+	var seconds float64
+	if len(parts) == 3 {
+		if seconds, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return 0, err
+		}
+	}
+
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)).Round(time.Microsecond)
+	return sign * d, nil
+}
+
+// String returns i in the "[-]HH:MM:SS[.ffffff]" form accepted by PostgreSQL.
+func (i Interval) String() string {
+	d := time.Duration(i)
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+	micros := (d % time.Second) / time.Microsecond
+
+	if micros == 0 {
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign, hours, minutes, seconds, micros)
+}
+
+// Scan implements sql.Scanner. Integers are taken as microseconds.
+func (i *Interval) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*i = Interval(time.Duration(v) * time.Microsecond)
+		return nil
+	case string:
+		parsed, err := ParseInterval(v)
+		*i = parsed
+		return err
+	case []byte:
+		parsed, err := ParseInterval(string(v))
+		*i = parsed
+		return err
+	}
+
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type *Interval", src)
+}
+
+// Value implements driver.Valuer
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
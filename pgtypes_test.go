@@ -0,0 +1,87 @@
+package goption
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]Money{
+		"$1,234.56": 123456,
+		"-$0.05":    -5,
+		"($1.00)":   -100,
+		"1234.5":    123450,
+		"€ 12":      1200,
+		"0.00":      0,
+	}
+	for text, expected := range cases {
+		if m, err := ParseMoney(text); err != nil || m != expected {
+			t.Errorf("ParseMoney(%q): expected %d, got %d (%v)", text, expected, m, err)
+		}
+	}
+
+	for _, invalid := range []string{"", "$", "1.234", "1.2.3", "12$", "1-2"} {
+		if _, err := ParseMoney(invalid); err == nil {
+			t.Errorf("Expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestMoneyScanValue(t *testing.T) {
+	var m Option[Money]
+	if err := m.Scan([]byte("-$1,000.10")); err != nil || m.Unwrap() != -100010 {
+		t.Errorf("Unexpected scanned money: %v (%v)", m, err)
+	}
+
+	if val, err := m.Value(); err != nil || val != "-1000.10" {
+		t.Errorf("Unexpected money value: %v (%v)", val, err)
+	}
+
+	if err := m.Scan(nil); err != nil || m.Ok() {
+		t.Errorf("Expected empty option for NULL, got %v (%v)", m, err)
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	cases := map[string]time.Duration{
+		"1 day 02:03:04":                      26*time.Hour + 3*time.Minute + 4*time.Second,
+		"3 days":                              72 * time.Hour,
+		"-1 days +02:00:00":                   -22 * time.Hour,
+		"00:00:01.5":                          1500 * time.Millisecond,
+		"-01:30:00":                           -90 * time.Minute,
+		"@ 1 day 2 hours 3 mins 4.5 secs ago": -(26*time.Hour + 3*time.Minute + 4500*time.Millisecond),
+		"1 week":                              7 * 24 * time.Hour,
+	}
+	for text, expected := range cases {
+		if i, err := ParseInterval(text); err != nil || time.Duration(i) != expected {
+			t.Errorf("ParseInterval(%q): expected %s, got %s (%v)", text, expected, time.Duration(i), err)
+		}
+	}
+
+	for _, invalid := range []string{"", "1 mon", "2 years", "1", "aa:bb", "1 day x"} {
+		if _, err := ParseInterval(invalid); err == nil {
+			t.Errorf("Expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestIntervalScanValue(t *testing.T) {
+	var i Option[Interval]
+	if err := i.Scan("1 day 02:03:04.000005"); err != nil {
+		t.Fatalf("Failed scanning interval: %s", err)
+	}
+
+	val, err := i.Value()
+	if err != nil || val != "26:03:04.000005" {
+		t.Errorf("Unexpected interval value: %v (%v)", val, err)
+	}
+
+	var back Interval
+	if err := back.Scan(val); err != nil || back != i.Unwrap() {
+		t.Errorf("Expected round trip, got %s (%v)", time.Duration(back), err)
+	}
+
+	if str := Interval(-90 * time.Minute).String(); str != "-01:30:00" {
+		t.Errorf("Unexpected interval string: %s", str)
+	}
+}
@@ -0,0 +1,113 @@
+package goption
+
+import (
+	"context"
+	"time"
+)
+
+// Stage describes one step of a traced Pipeline.
+type Stage struct {
+	// Name is the name the step was given.
+	Name string
+	// Op is the kind of step: "map", "flatmap" or "filter".
+	Op string
+	// In reports whether the value was present before the step.
+	In bool
+	// Out reports whether the value was present after the step.
+	Out bool
+	// Start is when the step started.
+	Start time.Time
+	// Duration is how long the step took.
+	Duration time.Duration
+}
+
+// Dropped reports whether the step turned a present value into an empty one.
+func (s Stage) Dropped() bool {
+	return s.In && !s.Out
+}
+
+// Tracer is notified of every step of a traced Pipeline. Implementations may
+// emit spans, e.g. OpenTelemetry spans using Start and Duration as the span
+// timestamps, or debug logs.
+type Tracer interface {
+	TraceStage(ctx context.Context, s Stage)
+}
+
+// TracerFunc adapts a function into a Tracer.
+type TracerFunc func(ctx context.Context, s Stage)
+
+// TraceStage implements Tracer
+func (f TracerFunc) TraceStage(ctx context.Context, s Stage) {
+	f(ctx, s)
+}
+
+// Pipeline is an optional value whose transformations are reported to a
+// Tracer, for diagnosing which step of a long chain produced an empty value.
+type Pipeline[T any] struct {
+	ctx    context.Context
+	opt    Option[T]
+	tracer Tracer
+}
+
+// WithTrace starts a Pipeline on o whose steps are reported to tracer.
+// A nil tracer disables tracing.
+func WithTrace[T any](ctx context.Context, o Option[T], tracer Tracer) Pipeline[T] {
+	return Pipeline[T]{
+		ctx:    ctx,
+		opt:    o,
+		tracer: tracer,
+	}
+}
+
+// Option returns the value at the end of the pipeline.
+func (p Pipeline[T]) Option() Option[T] {
+	return p.opt
+}
+
+// Filter empties the value unless pred returns true for it.
+func (p Pipeline[T]) Filter(name string, pred func(T) bool) Pipeline[T] {
+	return runStage(p, name, "filter", func(o Option[T]) Option[T] {
+		if o.ok && !pred(o.t) {
+			return None[T]()
+		}
+		return o
+	})
+}
+
+// PipelineMap applies f to the value of p.
+func PipelineMap[T, U any](p Pipeline[T], name string, f func(T) U) Pipeline[U] {
+	return runStage(p, name, "map", func(o Option[T]) Option[U] {
+		return Apply(o, f)
+	})
+}
+
+// PipelineFlatMap applies f to the value of p, continuing with its result.
+func PipelineFlatMap[T, U any](p Pipeline[T], name string, f func(T) Option[U]) Pipeline[U] {
+	return runStage(p, name, "flatmap", func(o Option[T]) Option[U] {
+		if !o.ok {
+			return None[U]()
+		}
+		return f(o.t)
+	})
+}
+
+func runStage[T, U any](p Pipeline[T], name, op string, step func(Option[T]) Option[U]) Pipeline[U] {
+	next := Pipeline[U]{ctx: p.ctx, tracer: p.tracer}
+	if p.tracer == nil {
+		next.opt = step(p.opt)
+		return next
+	}
+
+	start := time.Now()
+	next.opt = step(p.opt)
+	p.tracer.TraceStage(p.ctx, Stage{
+		Name:     name,
+		Op:       op,
+		In:       p.opt.ok,
+		Out:      next.opt.ok,
+		Start:    start,
+		Duration: time.Since(start),
+	})
+
+	return next
+}
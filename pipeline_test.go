@@ -0,0 +1,60 @@
+package goption
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestPipelineTrace(t *testing.T) {
+	var stages []Stage
+	tracer := TracerFunc(func(_ context.Context, s Stage) {
+		stages = append(stages, s)
+	})
+
+	p := WithTrace(context.Background(), Some("12"), tracer)
+	parsed := PipelineFlatMap(p, "parse", func(s string) Option[int] {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(i)
+	})
+	doubled := PipelineMap(parsed, "double", func(i int) int { return i * 2 })
+	result := doubled.Filter("small", func(i int) bool { return i < 10 }).Option()
+
+	if result.Ok() {
+		t.Errorf("Expected filter to empty the value, got %v", result)
+	}
+	if doubled.Option().Unwrap() != 24 {
+		t.Errorf("Expected 24, got %v", doubled.Option())
+	}
+
+	expected := []struct {
+		name, op string
+		in, out  bool
+	}{
+		{"parse", "flatmap", true, true},
+		{"double", "map", true, true},
+		{"small", "filter", true, false},
+	}
+	if len(stages) != len(expected) {
+		t.Fatalf("Expected %d stages, got %v", len(expected), stages)
+	}
+	for i, e := range expected {
+		s := stages[i]
+		if s.Name != e.name || s.Op != e.op || s.In != e.in || s.Out != e.out {
+			t.Errorf("Unexpected stage %d: %+v", i, s)
+		}
+	}
+	if !stages[2].Dropped() || stages[1].Dropped() {
+		t.Errorf("Expected only the filter stage to drop the value")
+	}
+}
+
+func TestPipelineWithoutTracer(t *testing.T) {
+	p := WithTrace(context.Background(), None[int](), nil)
+	if PipelineMap(p, "noop", func(i int) int { return i }).Option().Ok() {
+		t.Errorf("Expected empty value")
+	}
+}
@@ -0,0 +1,73 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Pool hands out reusable *T decode targets, like for ScanStruct or
+// DecodeMap, where T is a struct. Targets put back into the pool are Reset
+// first. It's safe for concurrent use.
+type Pool[T any] struct {
+	pool    sync.Pool
+	options []optionField
+}
+
+// optionField locates an Option field in a struct.
+type optionField struct {
+	offset uintptr
+	typ    reflect.Type
+}
+
+// NewPool returns a Pool of *T. It panics if T isn't a struct.
+func NewPool[T any]() *Pool[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("goption: Pool of %s, must be a struct", t))
+	}
+
+	p := &Pool[T]{options: optionFields(t, 0, nil)}
+	p.pool.New = func() any { return new(T) }
+	return p
+}
+
+// Get returns a target whose Option fields are empty.
+func (p *Pool[T]) Get() *T {
+	return p.pool.Get().(*T)
+}
+
+// Put resets v and returns it to the pool.
+func (p *Pool[T]) Put(v *T) {
+	p.Reset(v)
+	p.pool.Put(v)
+}
+
+// Reset empties the Option fields of v, including those of nested and
+// embedded structs. Other fields and fields behind pointers are left
+// unchanged.
+func (p *Pool[T]) Reset(v *T) {
+	base := unsafe.Pointer(v)
+	for _, f := range p.options {
+		// A typed write, unlike clearing the bytes, keeps the garbage
+		// collector informed about the pointers of the Option.
+		reflect.NewAt(f.typ, unsafe.Add(base, f.offset)).Elem().SetZero()
+	}
+}
+
+// optionFields appends the Option fields of t, at offset in the outer
+// struct, to fields.
+func optionFields(t reflect.Type, offset uintptr, fields []optionField) []optionField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case isOptionType(f.Type):
+			fields = append(fields, optionField{offset: offset + f.Offset, typ: f.Type})
+		case isPlainStruct(f.Type):
+			fields = optionFields(f.Type, offset+f.Offset, fields)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,70 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+type pooledAudit struct {
+	CreatedAt Option[string]
+	Revision  int
+}
+
+type pooledRow struct {
+	pooledAudit
+	ID    Option[int64]
+	Name  Option[string]
+	Count int
+	Tags  Option[[]string]
+	Ref   *pooledAudit
+}
+
+func TestPool(t *testing.T) {
+	p := NewPool[pooledRow]()
+
+	row := p.Get()
+	if row.ID.Ok() || row.Name.Ok() {
+		t.Errorf("Expected empty target, got %+v", row)
+	}
+
+	ref := &pooledAudit{CreatedAt: Some("ref")}
+	*row = pooledRow{
+		pooledAudit: pooledAudit{CreatedAt: Some("now"), Revision: 2},
+		ID:          Some[int64](1),
+		Name:        Some("a"),
+		Count:       3,
+		Tags:        Some([]string{"x"}),
+		Ref:         ref,
+	}
+	p.Reset(row)
+
+	if row.CreatedAt.Ok() || row.ID.Ok() || row.Name.Ok() || row.Tags.Ok() {
+		t.Errorf("Expected options to be empty after Reset, got %+v", row)
+	}
+	if row.Revision != 2 || row.Count != 3 || row.Ref != ref || !ref.CreatedAt.Ok() {
+		t.Errorf("Expected other fields to be kept, got %+v", row)
+	}
+	if row.Tags.t != nil {
+		t.Errorf("Expected Reset to drop references, got %v", row.Tags)
+	}
+
+	p.Put(row)
+	if again := p.Get(); again.ID.Ok() {
+		t.Errorf("Expected reset target from pool, got %+v", again)
+	}
+}
+
+func TestPoolFields(t *testing.T) {
+	fields := optionFields(reflect.TypeOf(pooledRow{}), 0, nil)
+	// CreatedAt, ID, Name and Tags.
+	if len(fields) != 4 {
+		t.Errorf("Expected 4 option fields, got %v", fields)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for non-struct pool")
+		}
+	}()
+	NewPool[int]()
+}
@@ -0,0 +1,128 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Source describes where a Provenanced value came from.
+type Source int
+
+const (
+	// SourceUnknown is the source of values whose origin wasn't recorded.
+	SourceUnknown Source = iota
+	// SourceDB is the source of values scanned from a database.
+	SourceDB
+	// SourceDefault is the source of values filled in from a default.
+	SourceDefault
+	// SourceOverride is the source of values explicitly overridden.
+	SourceOverride
+)
+
+// String implements fmt.Stringer
+func (s Source) String() string {
+	switch s {
+	case SourceUnknown:
+		return "unknown"
+	case SourceDB:
+		return "db"
+	case SourceDefault:
+		return "default"
+	case SourceOverride:
+		return "override"
+	}
+
+	return fmt.Sprintf("Source(%d)", int(s))
+}
+
+// Provenanced is an Option which also records where its value came from.
+type Provenanced[T any] struct {
+	opt    Option[T]
+	source Source
+}
+
+// WithSource returns a Provenanced wrapping o whose value came from source.
+func WithSource[T any](o Option[T], source Source) Provenanced[T] {
+	return Provenanced[T]{
+		opt:    o,
+		source: source,
+	}
+}
+
+// FromDB returns a present Provenanced value which came from a database.
+func FromDB[T any](t T) Provenanced[T] {
+	return WithSource(Some(t), SourceDB)
+}
+
+// FromDefault returns a present Provenanced value which came from a default.
+func FromDefault[T any](t T) Provenanced[T] {
+	return WithSource(Some(t), SourceDefault)
+}
+
+// FromOverride returns a present Provenanced value which came from an override.
+func FromOverride[T any](t T) Provenanced[T] {
+	return WithSource(Some(t), SourceOverride)
+}
+
+// Option returns the underlying optional value.
+func (p Provenanced[T]) Option() Option[T] {
+	return p.opt
+}
+
+// Source returns where the value came from.
+func (p Provenanced[T]) Source() Source {
+	return p.source
+}
+
+// Ok returns if the value is present.
+func (p Provenanced[T]) Ok() bool {
+	return p.opt.ok
+}
+
+// Get returns the underlying value, its source and a boolean indicating if
+// it's present.
+func (p Provenanced[T]) Get() (T, Source, bool) {
+	return p.opt.t, p.source, p.opt.ok
+}
+
+// Unwrap forcefully unwraps the value.
+// If the value is not present this function will panic.
+func (p Provenanced[T]) Unwrap() T {
+	return p.opt.Unwrap()
+}
+
+// UnwrapOr unwraps the value if it's present, otherwise it returns def.
+func (p Provenanced[T]) UnwrapOr(def T) T {
+	return p.opt.UnwrapOr(def)
+}
+
+// Or returns p if its value is present, otherwise it returns def tagged with
+// SourceDefault.
+func (p Provenanced[T]) Or(def T) Provenanced[T] {
+	if p.opt.ok {
+		return p
+	}
+
+	return FromDefault(def)
+}
+
+// Override returns t tagged with SourceOverride, regardless of p.
+func (p Provenanced[T]) Override(t T) Provenanced[T] {
+	return FromOverride(t)
+}
+
+// Scan implements sql.Scanner, recording SourceDB as the source.
+func (p *Provenanced[T]) Scan(src any) error {
+	p.source = SourceDB
+	return p.opt.Scan(src)
+}
+
+// Value implements driver.Valuer
+func (p Provenanced[T]) Value() (driver.Value, error) {
+	return p.opt.Value()
+}
+
+// String implements fmt.Stringer
+func (p Provenanced[T]) String() string {
+	return fmt.Sprintf("%s (from %s)", p.opt.String(), p.source)
+}
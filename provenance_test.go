@@ -0,0 +1,62 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestProvenancedSources(t *testing.T) {
+	if v, src, ok := FromDB(1).Get(); !ok || v != 1 || src != SourceDB {
+		t.Errorf("Unexpected FromDB: %v %v %v", v, src, ok)
+	}
+
+	if src := FromDefault(1).Source(); src != SourceDefault {
+		t.Errorf("Expected default source, got %v", src)
+	}
+
+	if src := FromOverride(1).Source(); src != SourceOverride {
+		t.Errorf("Expected override source, got %v", src)
+	}
+
+	var empty Provenanced[int]
+	if empty.Ok() || empty.Source() != SourceUnknown {
+		t.Errorf("Expected empty value of unknown source, got %v", empty)
+	}
+}
+
+func TestProvenancedOr(t *testing.T) {
+	p := WithSource(None[int](), SourceDB).Or(5)
+	if p.Unwrap() != 5 || p.Source() != SourceDefault {
+		t.Errorf("Expected default fallback, got %v", p)
+	}
+
+	p = FromDB(3).Or(5)
+	if p.Unwrap() != 3 || p.Source() != SourceDB {
+		t.Errorf("Expected db value to be kept, got %v", p)
+	}
+
+	p = p.Override(7)
+	if p.Unwrap() != 7 || p.Source() != SourceOverride {
+		t.Errorf("Expected override, got %v", p)
+	}
+}
+
+func TestProvenancedScan(t *testing.T) {
+	var p Provenanced[int]
+	if err := p.Scan(int64(12)); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+	if p.Unwrap() != 12 || p.Source() != SourceDB {
+		t.Errorf("Unexpected scanned value: %v", p)
+	}
+
+	if err := p.Scan(nil); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+	if p.Ok() || p.Source() != SourceDB {
+		t.Errorf("Expected empty value from db, got %v", p)
+	}
+
+	if str := FromDefault(4).String(); str != "4 (from default)" {
+		t.Errorf("Unexpected string: %s", str)
+	}
+}
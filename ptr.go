@@ -0,0 +1,130 @@
+package goption
+
+import (
+	"reflect"
+)
+
+// Ptr returns a pointer to a copy of the underlying value if it's present,
+// otherwise it returns nil. This matches the *T convention used for optional
+// fields by Kubernetes APIs (k8s.io/utils/ptr); use FromPtr to convert back.
+func (o Option[T]) Ptr() *T {
+	if !o.ok {
+		return nil
+	}
+
+	t := o.t
+	return &t
+}
+
+// FromPtr returns an option holding a copy of *t, or an empty option if t is
+// nil. It's the inverse of Ptr, for pointer based optional fields of
+// protobuf messages, GORM models or Kubernetes APIs. It's the same as FromRef.
+func FromPtr[T any](t *T) Option[T] {
+	return FromRef(t)
+}
+
+// deepCopierInto is implemented by types generated by deepcopy-gen.
+type deepCopierInto[T any] interface {
+	DeepCopyInto(out *T)
+}
+
+// DeepCopyInto copies in into out, deep copying the underlying value like
+// deepcopy-gen generated code does. This lets deepcopy-gen generated code
+// handle Option fields. Values implementing DeepCopyInto(*T) are copied by
+// it, slices and maps are copied element by element, and pointers point to a
+// copy of their value, made by its DeepCopyInto method if it has one. Other
+// values, like structs without DeepCopyInto methods, are copied shallowly.
+func (in *Option[T]) DeepCopyInto(out *Option[T]) {
+	*out = *in
+	if !in.ok {
+		return
+	}
+
+	var maybeCopier any = &in.t
+	if copier, isCopier := maybeCopier.(deepCopierInto[T]); isCopier {
+		copier.DeepCopyInto(&out.t)
+		return
+	}
+
+	switch reflect.TypeOf(&in.t).Elem().Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Array, reflect.Interface:
+		reflect.ValueOf(&out.t).Elem().Set(deepCopyValue(reflect.ValueOf(&in.t).Elem()))
+	}
+}
+
+// deepCopyValue returns a deep copy of rv, see Option.DeepCopyInto.
+func deepCopyValue(rv reflect.Value) reflect.Value {
+	if copied, ok := deepCopyByMethod(rv); ok {
+		return copied
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCopyValue(rv.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCopyValue(rv.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return out
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type().Elem())
+		out.Elem().Set(deepCopyValue(rv.Elem()))
+		return out
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type()).Elem()
+		out.Set(deepCopyValue(rv.Elem()))
+		return out
+	}
+
+	return rv
+}
+
+// deepCopyByMethod copies rv with its DeepCopyInto(*T) method if it has one.
+func deepCopyByMethod(rv reflect.Value) (reflect.Value, bool) {
+	pt := reflect.PointerTo(rv.Type())
+	m, ok := pt.MethodByName("DeepCopyInto")
+	if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != pt || m.Type.NumOut() != 0 {
+		return reflect.Value{}, false
+	}
+
+	in := reflect.New(rv.Type())
+	in.Elem().Set(rv)
+	out := reflect.New(rv.Type())
+	m.Func.Call([]reflect.Value{in, out})
+	return out.Elem(), true
+}
+
+// DeepCopy returns a deep copy of in, see DeepCopyInto.
+func (in *Option[T]) DeepCopy() *Option[T] {
+	if in == nil {
+		return nil
+	}
+
+	out := new(Option[T])
+	in.DeepCopyInto(out)
+	return out
+}
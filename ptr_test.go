@@ -0,0 +1,119 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestPtr(t *testing.T) {
+	if ptr := None[int]().Ptr(); ptr != nil {
+		t.Errorf("Expected nil pointer for empty option, got %v", *ptr)
+	}
+
+	opt := Some(3)
+	ptr := opt.Ptr()
+	if ptr == nil || *ptr != 3 {
+		t.Fatalf("Expected pointer to 3, got %v", ptr)
+	}
+
+	*ptr = 4
+	if opt.Unwrap() != 3 {
+		t.Errorf("Expected pointer to a copy, option changed to %v", opt)
+	}
+
+	if back := FromPtr(ptr); back.Unwrap() != 4 {
+		t.Errorf("Expected round trip through FromPtr, got %v", back)
+	}
+	*ptr = 5
+	if back := FromPtr(ptr); back.Unwrap() != 5 {
+		t.Errorf("Expected FromPtr to copy the current value, got %v", back)
+	}
+	if back := FromPtr[int](nil); back.Ok() {
+		t.Errorf("Expected empty option from nil pointer, got %v", back)
+	}
+}
+
+// deepCopyList mimics a deepcopy-gen generated type.
+type deepCopyList struct {
+	Items []int
+}
+
+func (in *deepCopyList) DeepCopyInto(out *deepCopyList) {
+	*out = *in
+	if in.Items != nil {
+		out.Items = make([]int, len(in.Items))
+		copy(out.Items, in.Items)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	in := Some(deepCopyList{Items: []int{1, 2}})
+	out := in.DeepCopy()
+	out.UnwrapRef().Items[0] = 10
+	if in.Unwrap().Items[0] != 1 {
+		t.Errorf("Expected deep copy, original changed to %v", in)
+	}
+
+	var empty Option[deepCopyList]
+	if empty.DeepCopy().Ok() {
+		t.Errorf("Expected copy of empty option to be empty")
+	}
+
+	var nilOpt *Option[int]
+	if nilOpt.DeepCopy() != nil {
+		t.Errorf("Expected copy of nil to be nil")
+	}
+
+	plain := Some(5)
+	var plainOut Option[int]
+	plain.DeepCopyInto(&plainOut)
+	if plainOut.Unwrap() != 5 {
+		t.Errorf("Expected copied value 5, got %v", plainOut)
+	}
+}
+
+type deepCopyNode struct {
+	Name     string
+	Children []string
+}
+
+func (in *deepCopyNode) DeepCopyInto(out *deepCopyNode) {
+	*out = *in
+	out.Children = append([]string(nil), in.Children...)
+}
+
+func TestDeepCopyReferences(t *testing.T) {
+	tags := Some([]string{"a", "b"})
+	tagsCopy := tags.DeepCopy()
+	tagsCopy.Unwrap()[0] = "changed"
+	if tags.Unwrap()[0] != "a" {
+		t.Errorf("Expected the slice to be copied, original changed to %v", tags)
+	}
+
+	labels := Some(map[string][]int{"x": {1}})
+	labelsCopy := labels.DeepCopy()
+	labelsCopy.Unwrap()["x"][0] = 2
+	labelsCopy.Unwrap()["y"] = nil
+	if m := labels.Unwrap(); len(m) != 1 || m["x"][0] != 1 {
+		t.Errorf("Expected the map to be copied, original changed to %v", m)
+	}
+
+	node := Some(&deepCopyNode{Name: "n", Children: []string{"c"}})
+	nodeCopy := node.DeepCopy()
+	nodeCopy.Unwrap().Name = "changed"
+	nodeCopy.Unwrap().Children[0] = "changed"
+	if n := node.Unwrap(); n.Name != "n" || n.Children[0] != "c" {
+		t.Errorf("Expected the pointee to be deep copied, original changed to %+v", n)
+	}
+
+	nested := Some([]Option[[]int]{Some([]int{1})})
+	nestedCopy := nested.DeepCopy()
+	nestedCopy.Unwrap()[0].Unwrap()[0] = 2
+	if nested.Unwrap()[0].Unwrap()[0] != 1 {
+		t.Errorf("Expected nested options to be deep copied, original changed to %v", nested)
+	}
+
+	var nilSlice Option[[]string] = Some[[]string](nil)
+	if c := nilSlice.DeepCopy(); !c.Ok() || c.Unwrap() != nil {
+		t.Errorf("Expected nil slice to stay nil, got %#v", c)
+	}
+}
@@ -0,0 +1,83 @@
+package goption
+
+import (
+	"context"
+	"sync"
+)
+
+// MissingField is an empty value reported to a NoneRecorder.
+type MissingField struct {
+	// Field names the value, e.g. "user.email".
+	Field string
+	// Stage names where the value went missing, e.g. "load" or "lookup".
+	Stage string
+}
+
+// NoneRecorder collects the values which went missing while handling a
+// request, so responses can explain missing data instead of silently
+// omitting it. A nil *NoneRecorder discards reports. It's safe for
+// concurrent use.
+type NoneRecorder struct {
+	mu      sync.Mutex
+	missing []MissingField
+}
+
+// NewNoneRecorder returns an empty NoneRecorder.
+func NewNoneRecorder() *NoneRecorder {
+	return &NoneRecorder{}
+}
+
+// Record reports that field went missing at stage.
+func (r *NoneRecorder) Record(field, stage string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.missing = append(r.missing, MissingField{Field: field, Stage: stage})
+}
+
+// Missing returns the reports in the order they were recorded.
+func (r *NoneRecorder) Missing() []MissingField {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]MissingField(nil), r.missing...)
+}
+
+// Tracer returns a Tracer recording the stages of a Pipeline which drop the
+// value of field.
+func (r *NoneRecorder) Tracer(field string) Tracer {
+	return TracerFunc(func(ctx context.Context, s Stage) {
+		if s.Dropped() {
+			r.Record(field, s.Name)
+		}
+	})
+}
+
+type noneRecorderKey struct{}
+
+// WithNoneRecorder returns a copy of ctx carrying r.
+func WithNoneRecorder(ctx context.Context, r *NoneRecorder) context.Context {
+	return context.WithValue(ctx, noneRecorderKey{}, r)
+}
+
+// NoneRecorderFrom returns the NoneRecorder carried by ctx, or nil.
+func NoneRecorderFrom(ctx context.Context) *NoneRecorder {
+	r, _ := ctx.Value(noneRecorderKey{}).(*NoneRecorder)
+	return r
+}
+
+// RecordNone reports field as missing at stage to the NoneRecorder of ctx if
+// o is empty, and returns o.
+func RecordNone[T any](ctx context.Context, field, stage string, o Option[T]) Option[T] {
+	if !o.ok {
+		NoneRecorderFrom(ctx).Record(field, stage)
+	}
+
+	return o
+}
@@ -0,0 +1,45 @@
+package goption
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNoneRecorder(t *testing.T) {
+	r := NewNoneRecorder()
+	ctx := WithNoneRecorder(context.Background(), r)
+
+	if o := RecordNone(ctx, "user.name", "load", Some("ada")); o.Unwrap() != "ada" {
+		t.Errorf("Expected value to be returned, got %v", o)
+	}
+	if o := RecordNone(ctx, "user.email", "load", None[string]()); o.Ok() {
+		t.Errorf("Expected empty option to be returned, got %v", o)
+	}
+
+	p := WithTrace(ctx, Some(3), r.Tracer("user.age"))
+	p = p.Filter("adult", func(age int) bool { return age >= 18 })
+	PipelineMap(p, "format", func(age int) string { return "" })
+
+	expected := []MissingField{
+		{Field: "user.email", Stage: "load"},
+		{Field: "user.age", Stage: "adult"},
+	}
+	if missing := r.Missing(); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("Expected %v, got %v", expected, missing)
+	}
+}
+
+func TestNoneRecorderMissing(t *testing.T) {
+	if r := NoneRecorderFrom(context.Background()); r != nil {
+		t.Errorf("Expected no recorder, got %v", r)
+	}
+
+	// Reports without a recorder are discarded.
+	RecordNone(context.Background(), "field", "stage", None[int]())
+	var r *NoneRecorder
+	r.Record("field", "stage")
+	if missing := r.Missing(); missing != nil {
+		t.Errorf("Expected no reports, got %v", missing)
+	}
+}
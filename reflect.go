@@ -0,0 +1,63 @@
+package goption
+
+import (
+	"reflect"
+)
+
+// reflectOption is implemented by *Option[T]. It allows reflection based code
+// in this package to access options whose T is only known at runtime.
+type reflectOption interface {
+	// reflectGet returns the underlying value and if it's present.
+	reflectGet() (reflect.Value, bool)
+	// reflectSet makes the option present and returns its settable
+	// underlying value.
+	reflectSet() reflect.Value
+	// reflectClear empties the option.
+	reflectClear()
+	// reflectType returns T.
+	reflectType() reflect.Type
+}
+
+func (o *Option[T]) reflectGet() (reflect.Value, bool) {
+	return reflect.ValueOf(&o.t).Elem(), o.ok
+}
+
+func (o *Option[T]) reflectSet() reflect.Value {
+	o.ok = true
+	return reflect.ValueOf(&o.t).Elem()
+}
+
+func (o *Option[T]) reflectClear() {
+	*o = None[T]()
+}
+
+func (o *Option[T]) reflectType() reflect.Type {
+	return reflect.TypeOf(&o.t).Elem()
+}
+
+var reflectOptionType = reflect.TypeOf((*reflectOption)(nil)).Elem()
+
+// isOptionType reports whether t is an Option[T].
+func isOptionType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(reflectOptionType)
+}
+
+// asReflectOption returns v as a reflectOption if it's an addressable Option.
+func asReflectOption(v reflect.Value) (reflectOption, bool) {
+	if !v.CanAddr() || !isOptionType(v.Type()) {
+		return nil, false
+	}
+
+	return v.Addr().Interface().(reflectOption), true
+}
+
+// addressable returns v if it's addressable, otherwise an addressable copy.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	return cp
+}
@@ -0,0 +1,86 @@
+package goption
+
+import (
+	"os"
+	"sort"
+	"sync"
+)
+
+// Resolver picks a value from named sources in order of priority, like a
+// ladder of configuration fallbacks: e.g. a flag over an environment
+// variable over a file over a remote default. It's safe for concurrent use.
+type Resolver[T any] struct {
+	mu      sync.RWMutex
+	sources []resolverSource[T]
+}
+
+type resolverSource[T any] struct {
+	name     string
+	priority int
+	lookup   func() Option[T]
+}
+
+// Candidate is the value a source of a Resolver provided.
+type Candidate[T any] struct {
+	Source   string
+	Priority int
+	Value    Option[T]
+}
+
+// NewResolver returns a Resolver without sources.
+func NewResolver[T any]() *Resolver[T] {
+	return &Resolver[T]{}
+}
+
+// Register adds a source named name. Sources with higher priorities are
+// consulted first, sources of equal priority in the order they're registered.
+func (r *Resolver[T]) Register(name string, priority int, lookup func() Option[T]) *Resolver[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sources = append(r.sources, resolverSource[T]{name: name, priority: priority, lookup: lookup})
+	sort.SliceStable(r.sources, func(i, j int) bool {
+		return r.sources[i].priority > r.sources[j].priority
+	})
+	return r
+}
+
+// Resolve returns the value of the first source providing one and the name of
+// that source. If no source does the result is empty and the name is "".
+func (r *Resolver[T]) Resolve() (Option[T], string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.sources {
+		if o := s.lookup(); o.ok {
+			return o, s.name
+		}
+	}
+
+	return None[T](), ""
+}
+
+// Candidates consults every source and returns what each provided, in the
+// order Resolve consults them, for auditing why a value won.
+func (r *Resolver[T]) Candidates() []Candidate[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	candidates := make([]Candidate[T], len(r.sources))
+	for i, s := range r.sources {
+		candidates[i] = Candidate[T]{Source: s.name, Priority: s.priority, Value: s.lookup()}
+	}
+	return candidates
+}
+
+// LookupEnv returns a Resolver source reading the environment variable key.
+// Unset variables provide no value, set but empty ones provide "".
+func LookupEnv(key string) func() Option[string] {
+	return func() Option[string] {
+		v, ok := os.LookupEnv(key)
+		if !ok {
+			return None[string]()
+		}
+		return Some(v)
+	}
+}
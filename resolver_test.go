@@ -0,0 +1,44 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	flag := None[string]()
+	r := NewResolver[string]().
+		Register("file", 10, func() Option[string] { return Some("from-file") }).
+		Register("flag", 100, func() Option[string] { return flag }).
+		Register("env", 50, LookupEnv("GOPTION_TEST_RESOLVER")).
+		Register("default", 10, func() Option[string] { return Some("fallback") })
+
+	if v, source := r.Resolve(); v.Unwrap() != "from-file" || source != "file" {
+		t.Errorf("Expected file value, got %v from %q", v, source)
+	}
+
+	t.Setenv("GOPTION_TEST_RESOLVER", "")
+	if v, source := r.Resolve(); v.Unwrap() != "" || source != "env" {
+		t.Errorf("Expected empty env value, got %v from %q", v, source)
+	}
+
+	flag = Some("from-flag")
+	if v, source := r.Resolve(); v.Unwrap() != "from-flag" || source != "flag" {
+		t.Errorf("Expected flag value, got %v from %q", v, source)
+	}
+
+	candidates := r.Candidates()
+	var order []string
+	for _, c := range candidates {
+		order = append(order, c.Source)
+	}
+	if len(order) != 4 || order[0] != "flag" || order[1] != "env" || order[2] != "file" || order[3] != "default" {
+		t.Errorf("Unexpected candidate order: %v", order)
+	}
+	if !candidates[3].Value.Ok() || candidates[3].Priority != 10 {
+		t.Errorf("Unexpected default candidate: %+v", candidates[3])
+	}
+
+	if v, source := NewResolver[int]().Resolve(); v.Ok() || source != "" {
+		t.Errorf("Expected empty result without sources, got %v from %q", v, source)
+	}
+}
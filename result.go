@@ -0,0 +1,134 @@
+package goption
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNone is the error of Results made from empty options.
+var ErrNone = errors.New("empty option")
+
+// Result is either a value or the error which prevented computing it.
+type Result[T any] struct {
+	t   T
+	err error
+}
+
+// Ok returns a successful Result holding t.
+func Ok[T any](t T) Result[T] {
+	return Result[T]{t: t}
+}
+
+// Err returns a failed Result. A nil err is replaced by ErrNone so the Result
+// is still failed.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = ErrNone
+	}
+
+	return Result[T]{err: err}
+}
+
+// ResultOf lifts the results of a function returning (T, error) into a
+// Result.
+func ResultOf[T any](t T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok(t)
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err returns the error of r, or nil if it holds a value.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Get returns the value and error of r, like a function returning (T, error).
+func (r Result[T]) Get() (T, error) {
+	return r.t, r.err
+}
+
+// Unwrap returns the value of r. It panics with the error of r if it failed.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("Unwrapped failed result: %s", r.err))
+	}
+
+	return r.t
+}
+
+// UnwrapOr returns the value of r if it succeeded, otherwise def.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+
+	return r.t
+}
+
+// ToOption returns the value of r as an option, which is empty if r failed.
+func (r Result[T]) ToOption() Option[T] {
+	if r.err != nil {
+		return None[T]()
+	}
+
+	return Some(r.t)
+}
+
+// Map applies f to the value of r if it succeeded. Use MapResult to
+// transform into another type.
+func (r Result[T]) Map(f func(T) T) Result[T] {
+	return MapResult(r, f)
+}
+
+// AndThen applies the fallible f to the value of r if it succeeded. Use the
+// package level AndThen to transform into another type.
+func (r Result[T]) AndThen(f func(T) (T, error)) Result[T] {
+	return AndThen(r, f)
+}
+
+// MapResult applies f to the value of r if it succeeded, otherwise it
+// propagates the error of r.
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return Ok(f(r.t))
+}
+
+// AndThen applies the fallible f to the value of r if it succeeded,
+// otherwise it propagates the error of r.
+func AndThen[T, U any](r Result[T], f func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return ResultOf(f(r.t))
+}
+
+// OkOr returns the value of o as a successful Result, or a Result failed
+// with err if o is empty.
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.ok {
+		return Err[T](err)
+	}
+
+	return Ok(o.t)
+}
+
+// AndThenResult applies the fallible f to the value of o. The Result fails
+// with ErrNone if o is empty, or with the error of f.
+func AndThenResult[T, U any](o Option[T], f func(T) (U, error)) Result[U] {
+	if !o.ok {
+		return Err[U](ErrNone)
+	}
+
+	return ResultOf(f(o.t))
+}
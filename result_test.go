@@ -0,0 +1,102 @@
+package goption
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	if v, err := Ok(3).Get(); err != nil || v != 3 {
+		t.Errorf("Expected 3, got %v (%v)", v, err)
+	}
+
+	failure := errors.New("failure")
+	r := Err[int](failure)
+	if r.IsOk() || r.Err() != failure {
+		t.Errorf("Expected failed result, got %v", r.Err())
+	}
+	if r := Err[int](nil); r.IsOk() || r.Err() != ErrNone {
+		t.Errorf("Expected nil error to be replaced, got %v", r.Err())
+	}
+}
+
+func TestAndThenResult(t *testing.T) {
+	if v, err := AndThenResult(Some("42"), strconv.Atoi).Get(); err != nil || v != 42 {
+		t.Errorf("Expected 42, got %v (%v)", v, err)
+	}
+
+	var numErr *strconv.NumError
+	if err := AndThenResult(Some("x"), strconv.Atoi).Err(); !errors.As(err, &numErr) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+	if err := AndThenResult(None[string](), strconv.Atoi).Err(); err != ErrNone {
+		t.Errorf("Expected ErrNone, got %v", err)
+	}
+}
+
+func TestResultOf(t *testing.T) {
+	if r := ResultOf(strconv.Atoi("7")); r.Unwrap() != 7 {
+		t.Errorf("Expected 7, got %v", r.Err())
+	}
+	if r := ResultOf(strconv.Atoi("x")); r.IsOk() || r.UnwrapOr(-1) != -1 {
+		t.Errorf("Expected failed result, got %v", r.Unwrap())
+	}
+}
+
+func TestResultUnwrap(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected unwrapping a failed result to panic")
+		}
+	}()
+	Err[int](errors.New("failure")).Unwrap()
+}
+
+func TestResultCombinators(t *testing.T) {
+	failure := errors.New("failure")
+	double := func(i int) int { return i * 2 }
+
+	if v := Ok(2).Map(double).Map(double).Unwrap(); v != 8 {
+		t.Errorf("Expected 8, got %v", v)
+	}
+	if err := Err[int](failure).Map(double).Err(); err != failure {
+		t.Errorf("Expected error to propagate, got %v", err)
+	}
+	if v := MapResult(Ok(3), strconv.Itoa).Unwrap(); v != "3" {
+		t.Errorf("Expected \"3\", got %q", v)
+	}
+
+	if v := AndThen(Ok("12"), strconv.Atoi).Unwrap(); v != 12 {
+		t.Errorf("Expected 12, got %v", v)
+	}
+	if err := AndThen(Err[string](failure), strconv.Atoi).Err(); err != failure {
+		t.Errorf("Expected error to propagate, got %v", err)
+	}
+	positive := func(i int) (int, error) {
+		if i <= 0 {
+			return 0, failure
+		}
+		return i, nil
+	}
+	if err := Ok(-1).AndThen(positive).Err(); err != failure {
+		t.Errorf("Expected error of f, got %v", err)
+	}
+
+	if o := Ok(1).ToOption(); o.Unwrap() != 1 {
+		t.Errorf("Expected 1, got %v", o)
+	}
+	if o := Err[int](failure).ToOption(); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+}
+
+func TestOkOr(t *testing.T) {
+	failure := errors.New("failure")
+	if v, err := Some(1).OkOr(failure).Get(); err != nil || v != 1 {
+		t.Errorf("Expected 1, got %v (%v)", v, err)
+	}
+	if err := None[int]().OkOr(failure).Err(); err != failure {
+		t.Errorf("Expected failure, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package goption
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/olachat/goption/convert"
+)
+
+// ScanPlan scans rows with a fixed set of columns into structs of type T
+// without resolving fields per row. Fields are matched to columns like
+// ScanStruct matches them, and the conversion of each column is chosen once
+// from the field type. Options and plain fields of the types drivers return,
+// and Options of other numeric types, are stored without reflection. A
+// ScanPlan is safe for concurrent use.
+type ScanPlan[T any] struct {
+	columns []planColumn
+}
+
+// planColumn is how one column is stored.
+type planColumn struct {
+	name string
+	// offset locates the field in T if it isn't behind an embedded pointer,
+	// otherwise index is used.
+	offset uintptr
+	index  []int
+	scan   planScanFunc
+}
+
+// planScanFunc stores src into the field at p.
+type planScanFunc func(p unsafe.Pointer, src any) error
+
+// NewScanPlan returns a ScanPlan for scanning rows with columns into T,
+// which must be a struct type. Columns without a matching field are an
+// error.
+func NewScanPlan[T any](columns []*sql.ColumnType) (*ScanPlan[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported scan plan type %s, must be a struct", t)
+	}
+
+	fields := scanFieldsOf(t)
+	plan := &ScanPlan[T]{columns: make([]planColumn, len(columns))}
+	for i, column := range columns {
+		name := column.Name()
+		f, ok := fields[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("no field of %s matches column %q", t, name)
+		}
+
+		offset, ft, direct := fieldOffset(t, f.index)
+		pc := planColumn{name: name, offset: offset, scan: planScanFor(ft)}
+		if !direct {
+			pc.index = f.index
+		}
+		plan.columns[i] = pc
+	}
+
+	return plan, nil
+}
+
+// fieldOffset returns the offset and type of the field at index in t, and
+// whether the field is stored in t rather than behind an embedded pointer.
+func fieldOffset(t reflect.Type, index []int) (uintptr, reflect.Type, bool) {
+	var offset uintptr
+	direct := true
+	for _, i := range index {
+		if t.Kind() == reflect.Pointer {
+			t, direct = t.Elem(), false
+		}
+		f := t.Field(i)
+		offset += f.Offset
+		t = f.Type
+	}
+
+	return offset, t, direct
+}
+
+// Scan scans the current row of rows into dst.
+func (p *ScanPlan[T]) Scan(rows Rows, dst *T) error {
+	values := make([]any, len(p.columns))
+	targets := make([]any, len(p.columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+
+	base := unsafe.Pointer(dst)
+	for i := range p.columns {
+		c := &p.columns[i]
+		field := unsafe.Add(base, c.offset)
+		if c.index != nil {
+			fv := fieldByIndex(reflect.ValueOf(dst).Elem(), c.index, true)
+			if !fv.IsValid() {
+				return fmt.Errorf("cannot set column %q behind a nil pointer to an unexported struct", c.name)
+			}
+			field = fv.Addr().UnsafePointer()
+		}
+
+		if err := c.scan(field, values[i]); err != nil {
+			return FieldError{Column: c.name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// planScanFor returns how to store column values into fields of type ft.
+func planScanFor(ft reflect.Type) planScanFunc {
+	if scan, ok := planScans[ft]; ok {
+		return scan
+	}
+
+	if reflect.PointerTo(ft).Implements(scannerType) {
+		return func(p unsafe.Pointer, src any) error {
+			return reflect.NewAt(ft, p).Interface().(sql.Scanner).Scan(src)
+		}
+	}
+	return func(p unsafe.Pointer, src any) error {
+		return convert.Assign(reflect.NewAt(ft, p).Interface(), src)
+	}
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// planScans are the scan functions of field types which don't need
+// reflection.
+var planScans = map[reflect.Type]planScanFunc{
+	reflect.TypeOf(Option[int64]{}):     directScan[int64],
+	reflect.TypeOf(Option[float64]{}):   directScan[float64],
+	reflect.TypeOf(Option[bool]{}):      directScan[bool],
+	reflect.TypeOf(Option[string]{}):    directScan[string],
+	reflect.TypeOf(Option[[]byte]{}):    directScan[[]byte],
+	reflect.TypeOf(Option[time.Time]{}): directScan[time.Time],
+
+	reflect.TypeOf(Option[int]{}):     intScan[int],
+	reflect.TypeOf(Option[int32]{}):   intScan[int32],
+	reflect.TypeOf(Option[int16]{}):   intScan[int16],
+	reflect.TypeOf(Option[int8]{}):    intScan[int8],
+	reflect.TypeOf(Option[uint32]{}):  intScan[uint32],
+	reflect.TypeOf(Option[uint16]{}):  intScan[uint16],
+	reflect.TypeOf(Option[uint8]{}):   intScan[uint8],
+	reflect.TypeOf(Option[float32]{}): float32Scan,
+
+	reflect.TypeOf(int64(0)):    plainScan[int64],
+	reflect.TypeOf(float64(0)):  plainScan[float64],
+	reflect.TypeOf(false):       plainScan[bool],
+	reflect.TypeOf(""):          plainScan[string],
+	reflect.TypeOf([]byte(nil)): plainScan[[]byte],
+	reflect.TypeOf(time.Time{}): plainScan[time.Time],
+}
+
+// directScan assigns src if it is a T and otherwise converts it like Scan.
+// Scanned []byte values are already copied by database/sql.
+func directScan[T any](p unsafe.Pointer, src any) error {
+	o := (*Option[T])(p)
+	if v, ok := src.(T); ok {
+		o.t, o.ok = v, true
+		return nil
+	}
+
+	return o.Scan(src)
+}
+
+// plainScan assigns src if it is a T and otherwise converts it like
+// convert.Assign.
+func plainScan[T any](p unsafe.Pointer, src any) error {
+	if v, ok := src.(T); ok {
+		*(*T)(p) = v
+		return nil
+	}
+
+	return convert.Assign((*T)(p), src)
+}
+
+// intScan converts int64 values which fit into T and otherwise converts src
+// like Scan.
+func intScan[T int | int32 | int16 | int8 | uint32 | uint16 | uint8](p unsafe.Pointer, src any) error {
+	o := (*Option[T])(p)
+	if v, ok := src.(int64); ok && int64(T(v)) == v {
+		o.t, o.ok = T(v), true
+		return nil
+	}
+
+	return o.Scan(src)
+}
+
+// float32Scan converts float64 values which are exact float32 values and
+// otherwise converts src like Scan.
+func float32Scan(p unsafe.Pointer, src any) error {
+	o := (*Option[float32])(p)
+	if v, ok := src.(float64); ok && float64(float32(v)) == v {
+		o.t, o.ok = float32(v), true
+		return nil
+	}
+
+	return o.Scan(src)
+}
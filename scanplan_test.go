@@ -0,0 +1,173 @@
+package goption
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// planDriver serves a fixed result for any query.
+type planDriver struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d *planDriver) Open(name string) (driver.Conn, error) {
+	return &planConn{d: d}, nil
+}
+
+type planConn struct {
+	d *planDriver
+}
+
+func (c *planConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *planConn) Close() error {
+	return nil
+}
+
+func (c *planConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *planConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return &planRows{d: c.d}, nil
+}
+
+type planRows struct {
+	d *planDriver
+	i int
+}
+
+func (r *planRows) Columns() []string {
+	return r.d.columns
+}
+
+func (r *planRows) Close() error {
+	return nil
+}
+
+func (r *planRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.d.rows) {
+		return io.EOF
+	}
+	copy(dest, r.d.rows[r.i])
+	r.i++
+	return nil
+}
+
+type planConnector struct {
+	d *planDriver
+}
+
+func (c planConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.d.Open("")
+}
+
+func (c planConnector) Driver() driver.Driver {
+	return c.d
+}
+
+func queryPlanRows(t *testing.T, d *planDriver) *sql.Rows {
+	t.Helper()
+	db := sql.OpenDB(planConnector{d: d})
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Failed querying: %s", err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+type plannedUser struct {
+	auditColumns
+	*Versioned
+	ID     Option[int64]  `db:"id"`
+	Name   Option[string] `db:"name"`
+	Age    Option[int16]  `db:"age"`
+	Score  Option[float32]
+	Active bool
+	Tags   Option[[]byte] `db:"tags"`
+}
+
+func TestScanPlan(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := queryPlanRows(t, &planDriver{
+		columns: []string{"id", "NAME", "age", "score", "active", "tags", "created_at", "version"},
+		rows: [][]driver.Value{
+			{int64(1), "ada", int64(36), 1.5, true, []byte("a,b"), created, int64(2)},
+			{int64(2), []byte("bob"), "40", nil, int64(0), nil, nil, nil},
+		},
+	})
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("Failed getting column types: %s", err)
+	}
+	plan, err := NewScanPlan[plannedUser](columns)
+	if err != nil {
+		t.Fatalf("Failed planning: %s", err)
+	}
+
+	var users []plannedUser
+	for rows.Next() {
+		var u plannedUser
+		if err := plan.Scan(rows, &u); err != nil {
+			t.Fatalf("Failed scanning: %s", err)
+		}
+		users = append(users, u)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+
+	ada, bob := users[0], users[1]
+	if ada.ID.Unwrap() != 1 || ada.Name.Unwrap() != "ada" || ada.Age.Unwrap() != 36 || ada.Score.Unwrap() != 1.5 ||
+		!ada.Active || string(ada.Tags.Unwrap()) != "a,b" || !ada.CreatedAt.Unwrap().Equal(created) || ada.Version.Unwrap() != 2 {
+		t.Errorf("Unexpected first row: %+v", ada)
+	}
+	if bob.ID.Unwrap() != 2 || bob.Name.Unwrap() != "bob" || bob.Age.Unwrap() != 40 || bob.Score.Ok() ||
+		bob.Active || bob.Tags.Ok() || bob.CreatedAt.Ok() || bob.Versioned == nil || bob.Version.Ok() {
+		t.Errorf("Unexpected second row: %+v", bob)
+	}
+}
+
+func TestScanPlanErrors(t *testing.T) {
+	rows := queryPlanRows(t, &planDriver{
+		columns: []string{"id", "email"},
+	})
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("Failed getting column types: %s", err)
+	}
+	if _, err := NewScanPlan[plannedUser](columns); err == nil {
+		t.Errorf("Expected error for unknown column")
+	}
+	if _, err := NewScanPlan[int](columns[:1]); err == nil {
+		t.Errorf("Expected error for non-struct type")
+	}
+
+	rows = queryPlanRows(t, &planDriver{
+		columns: []string{"age"},
+		rows:    [][]driver.Value{{int64(1 << 20)}},
+	})
+	columns, _ = rows.ColumnTypes()
+	plan, err := NewScanPlan[plannedUser](columns)
+	if err != nil {
+		t.Fatalf("Failed planning: %s", err)
+	}
+	rows.Next()
+	var u plannedUser
+	var fieldErr FieldError
+	if err := plan.Scan(rows, &u); !errors.As(err, &fieldErr) || fieldErr.Column != "age" {
+		t.Errorf("Expected field error for overflowing column, got %v", err)
+	}
+}
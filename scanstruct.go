@@ -0,0 +1,150 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/olachat/goption/convert"
+)
+
+// Rows is the part of *sql.Rows used by ScanStruct.
+type Rows interface {
+	Columns() ([]string, error)
+	Scan(dest ...any) error
+}
+
+// ScanStruct scans the current row of rows into dst, which must be a pointer
+// to a struct. Columns are matched to fields by their db tag, or else case
+// insensitively by field name. Fields of embedded structs are promoted like
+// encoding/json promotes them, so embedded audit columns can be scanned
+// directly. Columns without a matching field are an error.
+func ScanStruct(rows Rows, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported scan destination %T, must be a pointer to a struct", dst)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	targets, err := scanTargets(rv.Elem(), columns)
+	if err != nil {
+		return err
+	}
+
+	return rows.Scan(targets...)
+}
+
+// scanTargets returns pointers to the fields of rv matching columns.
+func scanTargets(rv reflect.Value, columns []string) ([]any, error) {
+	fields := scanFieldsOf(rv.Type())
+	targets := make([]any, len(columns))
+	for i, column := range columns {
+		fv, err := scanTarget(rv, fields, column)
+		if err != nil {
+			return nil, err
+		}
+		targets[i] = fv.Addr().Interface()
+	}
+
+	return targets, nil
+}
+
+// scanTarget returns the field of rv matching column.
+func scanTarget(rv reflect.Value, fields map[string]structField, column string) (reflect.Value, error) {
+	f, ok := fields[strings.ToLower(column)]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("no field of %s matches column %q", rv.Type(), column)
+	}
+
+	fv := fieldByIndex(rv, f.index, true)
+	if !fv.IsValid() {
+		return reflect.Value{}, fmt.Errorf("cannot set column %q behind a nil pointer to an unexported struct", column)
+	}
+	return fv, nil
+}
+
+// FieldError describes why ScanStructPartial couldn't scan a column.
+type FieldError struct {
+	Column string
+	Err    error
+}
+
+// Error implements error
+func (e FieldError) Error() string {
+	return fmt.Sprintf("column %q: %s", e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ScanStructPartial is like ScanStruct but scans every column it can. Fields
+// whose column can't be converted are left empty, or zero if they aren't
+// Options, and reported along with columns without a matching field. The
+// returned error is only set if the row couldn't be read at all.
+func ScanStructPartial(rows Rows, dst any) ([]FieldError, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported scan destination %T, must be a pointer to a struct", dst)
+	}
+	rv = rv.Elem()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	var failed []FieldError
+	fields := scanFieldsOf(rv.Type())
+	for i, column := range columns {
+		fv, err := scanTarget(rv, fields, column)
+		if err == nil {
+			err = convert.Assign(fv.Addr().Interface(), values[i])
+			if err != nil {
+				if opt, isOption := asReflectOption(fv); isOption {
+					opt.reflectClear()
+				} else {
+					fv.SetZero()
+				}
+			}
+		}
+		if err != nil {
+			failed = append(failed, FieldError{Column: column, Err: err})
+		}
+	}
+
+	return failed, nil
+}
+
+var scanFieldCache sync.Map // map[reflect.Type]map[string]structField
+
+// scanFieldsOf returns the fields of t keyed by lower case column name.
+func scanFieldsOf(t reflect.Type) map[string]structField {
+	if cached, ok := scanFieldCache.Load(t); ok {
+		return cached.(map[string]structField)
+	}
+
+	fields := cachedFields(t, "db", true)
+	byColumn := make(map[string]structField, len(fields))
+	for _, f := range fields {
+		byColumn[strings.ToLower(f.name)] = f
+	}
+
+	cached, _ := scanFieldCache.LoadOrStore(t, byColumn)
+	return cached.(map[string]structField)
+}
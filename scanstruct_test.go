@@ -0,0 +1,159 @@
+package goption
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/olachat/goption/convert"
+)
+
+// fakeRows is a single row result.
+type fakeRows struct {
+	columns []string
+	values  []any
+}
+
+func (r *fakeRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if len(dest) != len(r.values) {
+		return errors.New("wrong number of destinations")
+	}
+	for i, d := range dest {
+		if err := d.(sql.Scanner).Scan(r.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignRows is a single row result which, like sql.Rows, also scans into
+// destinations which aren't sql.Scanners.
+type assignRows struct {
+	fakeRows
+}
+
+func (r *assignRows) Scan(dest ...any) error {
+	if len(dest) != len(r.values) {
+		return errors.New("wrong number of destinations")
+	}
+	for i, d := range dest {
+		if err := convert.Assign(d, r.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type auditColumns struct {
+	CreatedAt Option[time.Time] `db:"created_at"`
+	UpdatedAt Option[time.Time] `db:"updated_at"`
+	DeletedAt Option[time.Time] `db:"deleted_at"`
+}
+
+type Versioned struct {
+	ID      Option[int64] // hidden by scannedUser.ID
+	Version Option[int64] `db:"version"`
+}
+
+type scannedUser struct {
+	auditColumns
+	*Versioned
+	ID   Option[int64]  `db:"id"`
+	Name Option[string] `db:"name"`
+}
+
+func TestScanStruct(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := &fakeRows{
+		columns: []string{"id", "Name", "created_at", "deleted_at", "version"},
+		values:  []any{int64(7), "ada", created, nil, int64(3)},
+	}
+
+	var u scannedUser
+	if err := ScanStruct(rows, &u); err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+
+	if u.ID.Unwrap() != 7 || u.Name.Unwrap() != "ada" {
+		t.Errorf("Unexpected direct fields: %v %v", u.ID, u.Name)
+	}
+	if !u.CreatedAt.Unwrap().Equal(created) || u.UpdatedAt.Ok() || u.DeletedAt.Ok() {
+		t.Errorf("Unexpected audit fields: %+v", u.auditColumns)
+	}
+	if u.Versioned == nil || u.Version.Unwrap() != 3 || u.Versioned.ID.Ok() {
+		t.Errorf("Unexpected embedded pointer fields: %+v", u.Versioned)
+	}
+}
+
+func TestScanStructUnknownColumn(t *testing.T) {
+	var u scannedUser
+	rows := &fakeRows{columns: []string{"id", "email"}, values: []any{int64(1), "x"}}
+	if err := ScanStruct(rows, &u); err == nil {
+		t.Errorf("Expected error for unknown column")
+	}
+	if err := ScanStruct(rows, u); err == nil {
+		t.Errorf("Expected error for non-pointer destination")
+	}
+}
+
+type hiddenVersion struct {
+	*versioned
+}
+
+type versioned struct {
+	Version Option[int64] `db:"version"`
+}
+
+func TestScanStructUnexportedPointer(t *testing.T) {
+	var h hiddenVersion
+	rows := &fakeRows{columns: []string{"version"}, values: []any{int64(1)}}
+	if err := ScanStruct(rows, &h); err == nil {
+		t.Errorf("Expected error for field behind unexported nil pointer")
+	}
+
+	h.versioned = &versioned{}
+	if err := ScanStruct(rows, &h); err != nil || h.Version.Unwrap() != 1 {
+		t.Errorf("Expected field behind allocated pointer to be set: %v (%v)", h.Version, err)
+	}
+}
+
+type partialRow struct {
+	ID    Option[int64]  `db:"id"`
+	Count Option[int32]  `db:"count"`
+	Name  Option[string] `db:"name"`
+	Score float64        `db:"score"`
+}
+
+func TestScanStructPartial(t *testing.T) {
+	rows := &assignRows{fakeRows{
+		columns: []string{"id", "count", "name", "score", "extra"},
+		values:  []any{int64(1), []byte("corrupt"), "ok", "not a float", "x"},
+	}}
+
+	r := partialRow{Count: Some[int32](9), Score: 2}
+	failed, err := ScanStructPartial(rows, &r)
+	if err != nil {
+		t.Fatalf("Failed scanning: %s", err)
+	}
+
+	if r.ID.Unwrap() != 1 || r.Name.Unwrap() != "ok" {
+		t.Errorf("Expected readable columns to be scanned, got %+v", r)
+	}
+	if r.Count.Ok() || r.Score != 0 {
+		t.Errorf("Expected failed columns to be cleared, got %+v", r)
+	}
+
+	if len(failed) != 3 {
+		t.Fatalf("Expected 3 failed columns, got %v", failed)
+	}
+	for i, column := range []string{"count", "score", "extra"} {
+		if failed[i].Column != column || failed[i].Err == nil {
+			t.Errorf("Unexpected failure %d: %v", i, failed[i])
+		}
+	}
+}
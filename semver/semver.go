@@ -0,0 +1,215 @@
+// Package semver implements semantic versions, as specified by
+// https://semver.org, with comparisons of goption.Options of them which
+// propagate empty options, for optional version constraints.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/olachat/goption"
+)
+
+// Version is a semantic version.
+type Version struct {
+	Major, Minor, Patch uint64
+	// Prerelease holds the dot separated prerelease identifiers, e.g.
+	// ["rc", "1"] for 1.0.0-rc.1.
+	Prerelease []string
+	// Build is the build metadata, which doesn't affect precedence.
+	Build string
+}
+
+// Parse parses a version such as "1.2.3-rc.1+build.5". A leading "v" is
+// allowed.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(s, "v")
+
+	rest, v.Build, _ = strings.Cut(rest, "+")
+	if strings.Contains(s, "+") && !validIdentifiers(v.Build, false) {
+		return Version{}, fmt.Errorf("invalid build metadata in version %q", s)
+	}
+
+	rest, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease {
+		if !validIdentifiers(prerelease, true) {
+			return Version{}, fmt.Errorf("invalid prerelease in version %q", s)
+		}
+		v.Prerelease = strings.Split(prerelease, ".")
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q, must be major.minor.patch", s)
+	}
+	for i, dst := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		if !isNumeric(parts[i]) {
+			return Version{}, fmt.Errorf("invalid version %q, %q isn't a number", s, parts[i])
+		}
+		n, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		*dst = n
+	}
+
+	return v, nil
+}
+
+// ParseSemverOption parses s like Parse, returning an empty option if s is
+// empty or invalid.
+func ParseSemverOption(s string) goption.Option[Version] {
+	v, err := Parse(s)
+	if err != nil {
+		return goption.None[Version]()
+	}
+	return goption.Some(v)
+}
+
+// isNumeric reports whether s is a number without leading zeros.
+func isNumeric(s string) bool {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validIdentifiers reports whether s is a dot separated list of non-empty
+// identifiers of ASCII letters, digits and hyphens. Numeric prerelease
+// identifiers may not have leading zeros.
+func validIdentifiers(s string, prerelease bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if prerelease && numeric && !isNumeric(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats v as a version without a leading "v".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Compare returns -1, 0 or 1 if v has lower, equal or higher precedence than
+// other. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	for _, c := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without a prerelease has higher precedence.
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.Prerelease) < len(other.Prerelease):
+		return -1
+	case len(v.Prerelease) > len(other.Prerelease):
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares prerelease identifiers: numerically if both are
+// numbers, lexically otherwise, with numbers lower than other identifiers.
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Compare compares the versions of a and b like Version.Compare, or returns
+// an empty option if either is empty.
+func Compare(a, b goption.Option[Version]) goption.Option[int] {
+	av, aOk := a.Get()
+	bv, bOk := b.Get()
+	if !aOk || !bOk {
+		return goption.None[int]()
+	}
+	return goption.Some(av.Compare(bv))
+}
+
+// Less reports whether a has lower precedence than b, or returns an empty
+// option if either is empty.
+func Less(a, b goption.Option[Version]) goption.Option[bool] {
+	return goption.Map(Compare(a, b), func(c int) bool { return c < 0 })
+}
+
+// AtLeast reports whether v has at least the precedence of min, or returns
+// an empty option if either is empty. Use UnwrapOr on the result to decide
+// how a missing version or constraint is treated.
+func AtLeast(v, min goption.Option[Version]) goption.Option[bool] {
+	return goption.Map(Compare(v, min), func(c int) bool { return c >= 0 })
+}
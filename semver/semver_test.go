@@ -0,0 +1,88 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/olachat/goption"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-rc.1+build.5")
+	if err != nil {
+		t.Fatalf("Failed parsing: %s", err)
+	}
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 || len(v.Prerelease) != 2 || v.Prerelease[1] != "1" || v.Build != "build.5" {
+		t.Errorf("Unexpected version: %+v", v)
+	}
+	if s := v.String(); s != "1.2.3-rc.1+build.5" {
+		t.Errorf("Expected 1.2.3-rc.1+build.5, got %s", s)
+	}
+
+	for _, s := range []string{"", "1.2", "1.2.3.4", "01.2.3", "1.2.x", "1.2.3-", "1.2.3-rc..1", "1.2.3-01", "1.2.3+", "1.2.3-r_c"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		}
+		if o := ParseSemverOption(s); o.Ok() {
+			t.Errorf("Expected empty option parsing %q, got %v", s, o)
+		}
+	}
+	if o := ParseSemverOption("0.0.1-alpha-1"); !o.Ok() {
+		t.Errorf("Expected version with hyphenated prerelease")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := range ordered {
+		for j := range ordered {
+			a, b := ParseSemverOption(ordered[i]), ParseSemverOption(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if c := Compare(a, b); c.Unwrap() != want {
+				t.Errorf("Expected comparing %s and %s to be %d, got %v", ordered[i], ordered[j], want, c)
+			}
+		}
+	}
+
+	if c := Compare(ParseSemverOption("1.0.0+a"), ParseSemverOption("1.0.0+b")); c.Unwrap() != 0 {
+		t.Errorf("Expected build metadata to be ignored, got %v", c)
+	}
+}
+
+func TestOptionComparisons(t *testing.T) {
+	v1, v2, none := ParseSemverOption("1.0.0"), ParseSemverOption("2.0.0"), goption.None[Version]()
+
+	if !Less(v1, v2).Unwrap() || Less(v2, v1).Unwrap() {
+		t.Errorf("Expected 1.0.0 to be less than 2.0.0")
+	}
+	if !AtLeast(v2, v1).Unwrap() || !AtLeast(v1, v1).Unwrap() || AtLeast(v1, v2).Unwrap() {
+		t.Errorf("Unexpected AtLeast results")
+	}
+	if Compare(v1, none).Ok() || Less(none, v1).Ok() || AtLeast(none, none).Ok() {
+		t.Errorf("Expected empty options to propagate")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var plugin struct {
+		MinVersion goption.Option[Version] `json:"min_version"`
+	}
+	if err := json.Unmarshal([]byte(`{"min_version":"1.4.0"}`), &plugin); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if plugin.MinVersion.Unwrap().Minor != 4 {
+		t.Errorf("Expected 1.4.0, got %v", plugin.MinVersion)
+	}
+	b, err := json.Marshal(plugin)
+	if err != nil || string(b) != `{"min_version":"1.4.0"}` {
+		t.Errorf("Expected version to marshal as a string, got %s (%v)", b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"min_version":"x"}`), &plugin); err == nil {
+		t.Errorf("Expected error for invalid version")
+	}
+}
@@ -0,0 +1,112 @@
+package goption
+
+// OptionSet is a set which remembers insertion order. Lookups which may not
+// find an element return Options.
+type OptionSet[T comparable] struct {
+	index map[T]int
+	items []T
+}
+
+// NewOptionSet returns a set containing items, in order.
+func NewOptionSet[T comparable](items ...T) *OptionSet[T] {
+	s := &OptionSet[T]{}
+	for _, item := range items {
+		s.Add(item)
+	}
+
+	return s
+}
+
+// Add inserts item at the end of the set unless it's already present. It
+// reports whether item was inserted.
+func (s *OptionSet[T]) Add(item T) bool {
+	if s.index == nil {
+		s.index = make(map[T]int)
+	}
+	if _, exists := s.index[item]; exists {
+		return false
+	}
+
+	s.index[item] = len(s.items)
+	s.items = append(s.items, item)
+	return true
+}
+
+// Remove removes item from the set, reporting whether it was present.
+func (s *OptionSet[T]) Remove(item T) bool {
+	i, exists := s.index[item]
+	if !exists {
+		return false
+	}
+
+	s.prune(i)
+	return true
+}
+
+// Contains reports whether item is in the set.
+func (s *OptionSet[T]) Contains(item T) bool {
+	_, exists := s.index[item]
+	return exists
+}
+
+// Len returns the number of elements in the set.
+func (s *OptionSet[T]) Len() int {
+	return len(s.items)
+}
+
+// Values returns the elements in insertion order.
+func (s *OptionSet[T]) Values() []T {
+	return append([]T(nil), s.items...)
+}
+
+// First returns the oldest element, if any.
+func (s *OptionSet[T]) First() Option[T] {
+	if len(s.items) == 0 {
+		return None[T]()
+	}
+
+	return Some(s.items[0])
+}
+
+// Last returns the newest element, if any.
+func (s *OptionSet[T]) Last() Option[T] {
+	if len(s.items) == 0 {
+		return None[T]()
+	}
+
+	return Some(s.items[len(s.items)-1])
+}
+
+// Pop removes and returns the newest element, if any.
+func (s *OptionSet[T]) Pop() Option[T] {
+	last := s.Last()
+	if last.ok {
+		s.prune(len(s.items) - 1)
+	}
+
+	return last
+}
+
+// Find returns the oldest element for which pred returns true, if any.
+func (s *OptionSet[T]) Find(pred func(T) bool) Option[T] {
+	for _, item := range s.items {
+		if pred(item) {
+			return Some(item)
+		}
+	}
+
+	return None[T]()
+}
+
+// prune removes the element at i, keeping the order of the others.
+func (s *OptionSet[T]) prune(i int) {
+	delete(s.index, s.items[i])
+	copy(s.items[i:], s.items[i+1:])
+	var zero T
+	s.items[len(s.items)-1] = zero
+	s.items = s.items[:len(s.items)-1]
+
+	for j := i; j < len(s.items); j++ {
+		s.index[s.items[j]] = j
+	}
+}
@@ -0,0 +1,55 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptionSet(t *testing.T) {
+	s := NewOptionSet(3, 1, 3, 2)
+	if !reflect.DeepEqual(s.Values(), []int{3, 1, 2}) {
+		t.Errorf("Expected deduplicated insertion order, got %v", s.Values())
+	}
+	if s.Add(1) || !s.Add(4) || s.Len() != 4 {
+		t.Errorf("Unexpected Add results, set is %v", s.Values())
+	}
+
+	if s.First() != Some(3) || s.Last() != Some(4) {
+		t.Errorf("Unexpected First/Last: %v %v", s.First(), s.Last())
+	}
+
+	if found := s.Find(func(i int) bool { return i < 3 }); found != Some(1) {
+		t.Errorf("Expected to find 1, got %v", found)
+	}
+	if found := s.Find(func(i int) bool { return i > 10 }); found.Ok() {
+		t.Errorf("Expected no match, got %v", found)
+	}
+
+	if !s.Remove(1) || s.Remove(1) || s.Contains(1) {
+		t.Errorf("Unexpected Remove results, set is %v", s.Values())
+	}
+	if !reflect.DeepEqual(s.Values(), []int{3, 2, 4}) {
+		t.Errorf("Expected order to be kept after removal, got %v", s.Values())
+	}
+	if !s.Contains(4) || s.Find(func(i int) bool { return i == 4 }) != Some(4) {
+		t.Errorf("Expected 4 to still be found after removal")
+	}
+
+	if s.Pop() != Some(4) || s.Pop() != Some(2) || s.Pop() != Some(3) || s.Pop().Ok() {
+		t.Errorf("Unexpected Pop sequence")
+	}
+	if s.First().Ok() || s.Len() != 0 {
+		t.Errorf("Expected empty set")
+	}
+}
+
+func TestOptionSetZeroValue(t *testing.T) {
+	var s OptionSet[string]
+	if s.Pop().Ok() || s.Remove("a") || s.Contains("a") {
+		t.Errorf("Expected empty zero set")
+	}
+	s.Add("a")
+	if s.First() != Some("a") {
+		t.Errorf("Expected zero set to be usable")
+	}
+}
@@ -0,0 +1,131 @@
+package goption
+
+// DedupeBy returns the options in opts with duplicate keys removed, keeping
+// the first occurrence of each key. At most one empty option is kept, at the
+// position of the first empty option in opts.
+func DedupeBy[T any, K comparable](opts []Option[T], key func(T) K) []Option[T] {
+	seen := make(map[K]struct{}, len(opts))
+	seenNone := false
+	out := make([]Option[T], 0, len(opts))
+	for _, o := range opts {
+		if !o.ok {
+			if !seenNone {
+				seenNone = true
+				out = append(out, o)
+			}
+			continue
+		}
+
+		k := key(o.t)
+		if _, dup := seen[k]; dup {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, o)
+	}
+
+	return out
+}
+
+// CompactNones removes every empty option from opts, preserving the order of
+// the remaining options. Like slices.DeleteFunc it modifies opts in place and
+// returns the shortened slice.
+func CompactNones[T any](opts []Option[T]) []Option[T] {
+	n := 0
+	for _, o := range opts {
+		if o.ok {
+			opts[n] = o
+			n++
+		}
+	}
+
+	var zero Option[T]
+	for i := n; i < len(opts); i++ {
+		opts[i] = zero
+	}
+
+	return opts[:n]
+}
+
+// Sequence returns the values of opts if all of them are present, otherwise
+// an empty option.
+func Sequence[T any](opts []Option[T]) Option[[]T] {
+	values := make([]T, len(opts))
+	for i, o := range opts {
+		if !o.ok {
+			return None[[]T]()
+		}
+		values[i] = o.t
+	}
+
+	return Some(values)
+}
+
+// Traverse applies f to every item and returns the values of the results if
+// all of them are present. It stops at the first empty result.
+func Traverse[T, U any](items []T, f func(T) Option[U]) Option[[]U] {
+	values := make([]U, len(items))
+	for i, item := range items {
+		o := f(item)
+		if !o.ok {
+			return None[[]U]()
+		}
+		values[i] = o.t
+	}
+
+	return Some(values)
+}
+
+// CollectSome returns the values of the present options in opts, in order.
+// Unlike CompactNones it leaves opts unchanged.
+func CollectSome[T any](opts []Option[T]) []T {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if o.ok {
+			values = append(values, o.t)
+		}
+	}
+
+	return values
+}
+
+// FilterMap applies f to every item and returns the values of the present
+// results, in order.
+func FilterMap[T, U any](items []T, f func(T) Option[U]) []U {
+	var values []U
+	for _, item := range items {
+		if o := f(item); o.ok {
+			values = append(values, o.t)
+		}
+	}
+
+	return values
+}
+
+// Partition splits opts into the values of the present options and the
+// indexes of the empty ones, both in order.
+func Partition[T any](opts []Option[T]) (values []T, missing []int) {
+	for i, o := range opts {
+		if o.ok {
+			values = append(values, o.t)
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	return values, missing
+}
+
+// PartitionSlice is like Partition, but only counts the empty options. For
+// mapping and filtering a single option, use FlatMap.
+func PartitionSlice[T any](opts []Option[T]) (values []T, noneCount int) {
+	for _, o := range opts {
+		if o.ok {
+			values = append(values, o.t)
+		} else {
+			noneCount++
+		}
+	}
+
+	return values, noneCount
+}
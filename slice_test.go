@@ -0,0 +1,125 @@
+package goption
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDedupeBy(t *testing.T) {
+	opts := []Option[string]{
+		Some("a"), None[string](), Some("A"), Some("b"), None[string](), Some("a"),
+	}
+	deduped := DedupeBy(opts, strings.ToLower)
+	expected := []Option[string]{Some("a"), None[string](), Some("b")}
+	if len(deduped) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deduped)
+	}
+	for i := range expected {
+		if deduped[i] != expected[i] {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, deduped[i])
+		}
+	}
+
+	if deduped := DedupeBy([]Option[int]{}, func(i int) int { return i }); len(deduped) != 0 {
+		t.Errorf("Expected empty result, got %v", deduped)
+	}
+}
+
+func TestCompactNones(t *testing.T) {
+	opts := []Option[int]{None[int](), Some(1), None[int](), Some(2), Some(3), None[int]()}
+	compacted := CompactNones(opts)
+	if len(compacted) != 3 {
+		t.Fatalf("Expected 3 options, got %v", compacted)
+	}
+	for i, o := range compacted {
+		if o.Unwrap() != i+1 {
+			t.Errorf("Expected %d at %d, got %v", i+1, i, o)
+		}
+	}
+
+	for _, o := range opts[3:] {
+		if o.Ok() {
+			t.Errorf("Expected tail of original slice to be cleared, got %v", opts)
+		}
+	}
+}
+
+func TestSequence(t *testing.T) {
+	if o := Sequence([]Option[int]{Some(1), Some(2)}); !reflect.DeepEqual(o.Unwrap(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", o)
+	}
+	if o := Sequence([]Option[int]{Some(1), None[int]()}); o.Ok() {
+		t.Errorf("Expected empty option, got %v", o)
+	}
+	if o := Sequence([]Option[int]{}); !o.Ok() || len(o.Unwrap()) != 0 {
+		t.Errorf("Expected empty slice, got %v", o)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	parse := func(s string) Option[int] {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(i)
+	}
+
+	if o := Traverse([]string{"1", "2"}, parse); !reflect.DeepEqual(o.Unwrap(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", o)
+	}
+
+	calls := 0
+	counted := func(s string) Option[int] {
+		calls++
+		return parse(s)
+	}
+	if o := Traverse([]string{"1", "x", "3"}, counted); o.Ok() || calls != 2 {
+		t.Errorf("Expected empty option after 2 calls, got %v after %d", o, calls)
+	}
+}
+
+func TestCollectSome(t *testing.T) {
+	opts := []Option[int]{Some(1), None[int](), Some(3)}
+	if values := CollectSome(opts); !reflect.DeepEqual(values, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", values)
+	}
+	if opts[1].Ok() || opts[2].Unwrap() != 3 {
+		t.Errorf("Expected opts to be unchanged, got %v", opts)
+	}
+	if values := CollectSome([]Option[int]{None[int]()}); len(values) != 0 {
+		t.Errorf("Expected no values, got %v", values)
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	parse := func(s string) Option[int] {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(i)
+	}
+	if values := FilterMap([]string{"1", "x", "3"}, parse); !reflect.DeepEqual(values, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", values)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	values, missing := Partition([]Option[string]{None[string](), Some("a"), None[string](), Some("b")})
+	if !reflect.DeepEqual(values, []string{"a", "b"}) || !reflect.DeepEqual(missing, []int{0, 2}) {
+		t.Errorf("Expected [a b] and [0 2], got %v and %v", values, missing)
+	}
+}
+
+func TestPartitionSlice(t *testing.T) {
+	values, noneCount := PartitionSlice([]Option[int]{Some(1), None[int](), None[int](), Some(2)})
+	if !reflect.DeepEqual(values, []int{1, 2}) || noneCount != 2 {
+		t.Errorf("Expected [1 2] and 2, got %v and %d", values, noneCount)
+	}
+	if values, noneCount := PartitionSlice[int](nil); values != nil || noneCount != 0 {
+		t.Errorf("Expected nothing, got %v and %d", values, noneCount)
+	}
+}
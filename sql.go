@@ -1,28 +1,83 @@
 package goption
 
 import (
-	"bytes"
-	"database/sql"
+	"context"
 	"database/sql/driver"
-	"errors"
 	"fmt"
+	"net"
+	"net/netip"
 	"reflect"
-	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/olachat/goption/convert"
 )
 
 // Scan implements sql.Scanner for Options
 func (o *Option[T]) Scan(src any) error {
-	if src == nil {
+	return o.scanCodec(nil, src)
+}
+
+func (o *Option[T]) scanCodec(c *Codec, src any) error {
+	if src == nil || c.isNull(src) {
 		o.ok, o.t = false, *new(T)
 		return nil
 	}
 
 	o.ok = true
-	return convertAssign(&o.t, src)
+	return c.convertAssign(&o.t, src)
+}
+
+// primitiveValue converts *t without reflection if it's one of the common
+// unnamed primitive types. Named types may implement driver.Valuer, so they
+// take the slow path.
+func primitiveValue(t any) (driver.Value, bool) {
+	switch t := t.(type) {
+	case *string:
+		return *t, true
+	case *int64:
+		return *t, true
+	case *int:
+		return int64(*t), true
+	case *int32:
+		return int64(*t), true
+	case *int16:
+		return int64(*t), true
+	case *int8:
+		return int64(*t), true
+	case *uint32:
+		return int64(*t), true
+	case *uint16:
+		return int64(*t), true
+	case *uint8:
+		return int64(*t), true
+	case *float64:
+		return *t, true
+	case *float32:
+		return float64(*t), true
+	case *bool:
+		return *t, true
+	case *[]byte:
+		return *t, true
+	case *time.Time:
+		return *t, true
+	}
+
+	return nil, false
 }
 
 func convertValue(v any) (any, error) {
+	switch v := v.(type) {
+	case netip.AddrPort:
+		if !v.IsValid() {
+			return nil, fmt.Errorf("invalid netip.AddrPort")
+		}
+		return v.String(), nil
+	case net.HardwareAddr:
+		return v.String(), nil
+	}
+
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Pointer:
@@ -66,315 +121,121 @@ func (o Option[T]) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
+	if !passthrough.any.Load() {
+		if v, ok := primitiveValue(&o.t); ok {
+			return v, nil
+		}
+	}
+
 	var maybeValuer any = o.t
+	if isPassthrough(maybeValuer) {
+		return maybeValuer, nil
+	}
+
 	if valuer, isValuer := maybeValuer.(driver.Valuer); isValuer {
 		return valuer.Value()
 	}
 
+	// database/sql passes decimals to drivers as is.
+	if decimal, isDecimal := maybeValuer.(DecimalDecomposer); isDecimal {
+		return decimal, nil
+	}
+
 	return convertValue(o.t)
 }
 
-var errNilPtr = errors.New("destination pointer is nil") // embedded in descriptive error
-
-type decimalDecompose interface {
-	// Decompose returns the internal decimal state in parts.
-	// If the provided buf has sufficient capacity, buf may be returned as the coefficient with
-	// the value set and length set as appropriate.
-	Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32)
+var passthrough struct {
+	mu    sync.RWMutex
+	types map[reflect.Type]struct{}
+	any   atomic.Bool
 }
 
-type decimalCompose interface {
-	// Compose sets the internal decimal value from parts. If the value cannot be
-	// represented then an error should be returned.
-	Compose(form byte, negative bool, coefficient []byte, exponent int32) error
-}
+// RegisterPassthrough makes Value return the values of options of the given
+// types as is, without calling their Value method or converting them. Use it
+// for argument types a driver handles natively, like pgtype values, since
+// drivers with a NamedValueChecker accept more than driver.Value. It's meant
+// to be called during initialization.
+func RegisterPassthrough(types ...reflect.Type) {
+	passthrough.mu.Lock()
+	defer passthrough.mu.Unlock()
 
-type RawBytes []byte
-
-// convertAssign copies to dest the value in src, converting it if possible.
-// An error is returned if the copy would result in loss of information.
-// dest should be a pointer type. If rows is passed in, the rows will
-// be used as the parent for any cursor values converted from a
-// driver.Rows to a *Rows.
-func convertAssign(dest, src any) error {
-	// Common cases, without reflect.
-	switch s := src.(type) {
-	case string:
-		switch d := dest.(type) {
-		case *string:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = s
-			return nil
-		case *[]byte:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = []byte(s)
-			return nil
-		case *RawBytes:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = append((*d)[:0], s...)
-			return nil
-		}
-	case []byte:
-		switch d := dest.(type) {
-		case *string:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = string(s)
-			return nil
-		case *any:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = bytes.Clone(s)
-			return nil
-		case *[]byte:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = bytes.Clone(s)
-			return nil
-		case *RawBytes:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = s
-			return nil
-		}
-	case time.Time:
-		switch d := dest.(type) {
-		case *time.Time:
-			*d = s
-			return nil
-		case *string:
-			*d = s.Format(time.RFC3339Nano)
-			return nil
-		case *[]byte:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = []byte(s.Format(time.RFC3339Nano))
-			return nil
-		case *RawBytes:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = s.AppendFormat((*d)[:0], time.RFC3339Nano)
-			return nil
-		}
-	case decimalDecompose:
-		switch d := dest.(type) {
-		case decimalCompose:
-			return d.Compose(s.Decompose(nil))
-		}
-	case nil:
-		switch d := dest.(type) {
-		case *any:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = nil
-			return nil
-		case *[]byte:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = nil
-			return nil
-		case *RawBytes:
-			if d == nil {
-				return errNilPtr
-			}
-			*d = nil
-			return nil
-		}
+	if passthrough.types == nil {
+		passthrough.types = make(map[reflect.Type]struct{})
 	}
-
-	var sv reflect.Value
-
-	switch d := dest.(type) {
-	case *string:
-		sv = reflect.ValueOf(src)
-		switch sv.Kind() {
-		case reflect.Bool,
-			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-			reflect.Float32, reflect.Float64:
-			*d = asString(src)
-			return nil
-		}
-	case *[]byte:
-		sv = reflect.ValueOf(src)
-		if b, ok := asBytes(nil, sv); ok {
-			*d = b
-			return nil
-		}
-	case *RawBytes:
-		sv = reflect.ValueOf(src)
-		if b, ok := asBytes([]byte(*d)[:0], sv); ok {
-			*d = RawBytes(b)
-			return nil
-		}
-	case *bool:
-		bv, err := driver.Bool.ConvertValue(src)
-		if err == nil {
-			*d = bv.(bool)
-		}
-		return err
-	case *any:
-		*d = src
-		return nil
+	for _, t := range types {
+		passthrough.types[t] = struct{}{}
 	}
+	passthrough.any.Store(len(passthrough.types) > 0)
+}
 
-	if scanner, ok := dest.(sql.Scanner); ok {
-		return scanner.Scan(src)
+func isPassthrough(v any) bool {
+	if !passthrough.any.Load() {
+		return false
 	}
 
-	dpv := reflect.ValueOf(dest)
-	if dpv.Kind() != reflect.Pointer {
-		return errors.New("destination not a pointer")
-	}
-	if dpv.IsNil() {
-		return errNilPtr
-	}
+	passthrough.mu.RLock()
+	defer passthrough.mu.RUnlock()
+	_, ok := passthrough.types[reflect.TypeOf(v)]
+	return ok
+}
 
-	if !sv.IsValid() {
-		sv = reflect.ValueOf(src)
-	}
+// ValuerContext is implemented by types whose conversion to a driver.Value
+// depends on a context, e.g. because the conversion is expensive and should
+// stop once the context is done.
+type ValuerContext interface {
+	ValueContext(ctx context.Context) (driver.Value, error)
+}
 
-	dv := reflect.Indirect(dpv)
-	if sv.IsValid() && sv.Type().AssignableTo(dv.Type()) {
-		switch b := src.(type) {
-		case []byte:
-			dv.Set(reflect.ValueOf(bytes.Clone(b)))
-		default:
-			dv.Set(sv)
-		}
-		return nil
+// ValueContext implements ValuerContext. If T implements ValuerContext, ctx
+// is passed through, otherwise it's like Value.
+func (o Option[T]) ValueContext(ctx context.Context) (driver.Value, error) {
+	if !o.ok {
+		return nil, nil
 	}
 
-	if dv.Kind() == sv.Kind() && sv.Type().ConvertibleTo(dv.Type()) {
-		dv.Set(sv.Convert(dv.Type()))
-		return nil
+	var maybeValuer any = o.t
+	if isPassthrough(maybeValuer) {
+		return maybeValuer, nil
 	}
 
-	// The following conversions use a string value as an intermediate representation
-	// to convert between various numeric types.
-	//
-	// This also allows scanning into user defined types such as "type Int int64".
-	// For symmetry, also check for string destination types.
-	switch dv.Kind() {
-	case reflect.Pointer:
-		if src == nil {
-			dv.SetZero()
-			return nil
-		}
-		dv.Set(reflect.New(dv.Type().Elem()))
-		return convertAssign(dv.Interface(), src)
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if src == nil {
-			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
-		}
-		s := asString(src)
-		i64, err := strconv.ParseInt(s, 10, dv.Type().Bits())
-		if err != nil {
-			err = strconvErr(err)
-			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, s, dv.Kind(), err)
-		}
-		dv.SetInt(i64)
-		return nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if src == nil {
-			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
-		}
-		s := asString(src)
-		u64, err := strconv.ParseUint(s, 10, dv.Type().Bits())
-		if err != nil {
-			err = strconvErr(err)
-			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, s, dv.Kind(), err)
-		}
-		dv.SetUint(u64)
-		return nil
-	case reflect.Float32, reflect.Float64:
-		if src == nil {
-			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
-		}
-		s := asString(src)
-		f64, err := strconv.ParseFloat(s, dv.Type().Bits())
-		if err != nil {
-			err = strconvErr(err)
-			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, s, dv.Kind(), err)
-		}
-		dv.SetFloat(f64)
-		return nil
-	case reflect.String:
-		if src == nil {
-			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
-		}
-		switch v := src.(type) {
-		case string:
-			dv.SetString(v)
-			return nil
-		case []byte:
-			dv.SetString(string(v))
-			return nil
-		}
+	if valuer, isValuer := maybeValuer.(ValuerContext); isValuer {
+		return valuer.ValueContext(ctx)
 	}
 
-	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+	return o.Value()
 }
 
-func strconvErr(err error) error {
-	if ne, ok := err.(*strconv.NumError); ok {
-		return ne.Err
-	}
-	return err
+// WithValueContext returns a driver.Valuer which converts v using ctx.
+// Pass it as a query argument so cancellation and deadlines reach v.
+func WithValueContext(ctx context.Context, v ValuerContext) driver.Valuer {
+	return contextValuer{ctx: ctx, v: v}
 }
 
-func asString(src any) string {
-	switch v := src.(type) {
-	case string:
-		return v
-	case []byte:
-		return string(v)
-	}
-	rv := reflect.ValueOf(src)
-	switch rv.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return strconv.FormatInt(rv.Int(), 10)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return strconv.FormatUint(rv.Uint(), 10)
-	case reflect.Float64:
-		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
-	case reflect.Float32:
-		return strconv.FormatFloat(rv.Float(), 'g', -1, 32)
-	case reflect.Bool:
-		return strconv.FormatBool(rv.Bool())
-	}
-	return fmt.Sprintf("%v", src)
+type contextValuer struct {
+	ctx context.Context
+	v   ValuerContext
 }
 
-func asBytes(buf []byte, rv reflect.Value) (b []byte, ok bool) {
-	switch rv.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return strconv.AppendInt(buf, rv.Int(), 10), true
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return strconv.AppendUint(buf, rv.Uint(), 10), true
-	case reflect.Float32:
-		return strconv.AppendFloat(buf, rv.Float(), 'g', -1, 32), true
-	case reflect.Float64:
-		return strconv.AppendFloat(buf, rv.Float(), 'g', -1, 64), true
-	case reflect.Bool:
-		return strconv.AppendBool(buf, rv.Bool()), true
-	case reflect.String:
-		s := rv.String()
-		return append(buf, s...), true
+// Value implements driver.Valuer
+func (c contextValuer) Value() (driver.Value, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
 	}
-	return
+
+	return c.v.ValueContext(c.ctx)
 }
+
+// DecimalDecomposer is implemented by decimal types which can be sent to drivers
+// losslessly, like the decimalDecompose interface of database/sql.
+type DecimalDecomposer = convert.DecimalDecomposer
+
+// DecimalComposer is implemented by decimal types which can be scanned
+// losslessly, like the decimalCompose interface of database/sql.
+type DecimalComposer = convert.DecimalComposer
+
+// ErrCursorUnsupported is returned when scanning a cursor, a nested
+// driver.Rows, into an Option of a type which can't hold it.
+var ErrCursorUnsupported = convert.ErrCursorUnsupported
+
+// RawBytes is a byte slice which may alias memory owned by the driver.
+type RawBytes = convert.RawBytes
@@ -1,8 +1,16 @@
 package goption
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
 	"testing"
 	"time"
 
@@ -157,3 +165,239 @@ func TestDummyImplementsDriverValuer(t *testing.T) {
 	valuer = dummyValuer{}
 	func(any) {}(valuer)
 }
+
+// dummyContextValuer implements ValuerContext
+type dummyContextValuer struct{}
+
+type dummyContextKey struct{}
+
+func (dummyContextValuer) ValueContext(ctx context.Context) (driver.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ctx.Value(dummyContextKey{}), nil
+}
+
+func TestValueContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), dummyContextKey{}, "from context")
+
+	val, err := Some(dummyContextValuer{}).ValueContext(ctx)
+	if err != nil || val != "from context" {
+		t.Errorf("Expected value from context, got %v (%v)", val, err)
+	}
+
+	val, err = WithValueContext(ctx, Some(dummyContextValuer{})).Value()
+	if err != nil || val != "from context" {
+		t.Errorf("Expected value from context, got %v (%v)", val, err)
+	}
+
+	val, err = WithValueContext(ctx, None[dummyContextValuer]()).Value()
+	if err != nil || val != nil {
+		t.Errorf("Expected nil for empty option, got %v (%v)", val, err)
+	}
+
+	val, err = WithValueContext(ctx, Some(3)).Value()
+	if err != nil || val != int64(3) {
+		t.Errorf("Expected fallback to Value, got %v (%v)", val, err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := WithValueContext(canceled, Some(dummyContextValuer{})).Value(); err != context.Canceled {
+		t.Errorf("Expected canceled context error, got %v", err)
+	}
+}
+
+// dummyDecimal implements DecimalComposer and DecimalDecomposer
+type dummyDecimal struct {
+	form        byte
+	negative    bool
+	coefficient big.Int
+	exponent    int32
+}
+
+func (d *dummyDecimal) Compose(form byte, negative bool, coefficient []byte, exponent int32) error {
+	d.form, d.negative, d.exponent = form, negative, exponent
+	d.coefficient.SetBytes(coefficient)
+	return nil
+}
+
+func (d dummyDecimal) Decompose(buf []byte) (byte, bool, []byte, int32) {
+	return d.form, d.negative, d.coefficient.Bytes(), d.exponent
+}
+
+func (d dummyDecimal) String() string {
+	sign := ""
+	if d.negative {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%se%d", sign, d.coefficient.String(), d.exponent)
+}
+
+func TestDecimalValue(t *testing.T) {
+	var dec dummyDecimal
+	dec.Compose(0, true, big.NewInt(12345).Bytes(), -2)
+
+	val, err := Some(dec).Value()
+	if err != nil {
+		t.Fatalf("Failed converting decimal: %s", err)
+	}
+	if !driver.IsValue(val) {
+		t.Errorf("Expected a valid driver value, got %T", val)
+	}
+	if decomposer, ok := val.(DecimalDecomposer); !ok {
+		t.Errorf("Expected decimal to be passed through, got %T", val)
+	} else if _, negative, coefficient, exponent := decomposer.Decompose(nil); !negative || new(big.Int).SetBytes(coefficient).Int64() != 12345 || exponent != -2 {
+		t.Errorf("Unexpected decimal: %v", val)
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	cases := []struct {
+		src      any
+		expected string
+	}{
+		{"-123.4500", "-1234500e-4"},
+		{[]byte("1.5e10"), "15e9"},
+		{int64(42), "42e0"},
+		{"123456789012345678901234567", "123456789012345678901234567e0"},
+	}
+	for _, c := range cases {
+		var dec Option[dummyDecimal]
+		if err := dec.Scan(c.src); err != nil {
+			t.Errorf("Failed scanning %v: %s", c.src, err)
+		} else if str := dec.Unwrap().String(); str != c.expected {
+			t.Errorf("Expected %s for %v, got %s", c.expected, c.src, str)
+		}
+	}
+
+	var dec Option[dummyDecimal]
+	if err := dec.Scan("NaN"); err != nil || dec.Unwrap().form != 2 {
+		t.Errorf("Expected NaN, got %v (%v)", dec, err)
+	}
+
+	if err := dec.Scan("12.3.4"); err == nil {
+		t.Errorf("Expected error scanning invalid decimal")
+	}
+}
+
+func TestNetValue(t *testing.T) {
+	ap := Some(netip.MustParseAddrPort("[::1]:80"))
+	if v, err := ap.Value(); err != nil || v != "[::1]:80" {
+		t.Errorf("Expected textual AddrPort, got %v (%v)", v, err)
+	}
+	if _, err := Some(netip.AddrPort{}).Value(); err == nil {
+		t.Errorf("Expected error for invalid AddrPort")
+	}
+
+	mac, _ := net.ParseMAC("08:00:2b:01:02:03")
+	if v, err := Some(mac).Value(); err != nil || v != "08:00:2b:01:02:03" {
+		t.Errorf("Expected textual MAC, got %v (%v)", v, err)
+	}
+
+	var scanned Option[net.HardwareAddr]
+	if err := scanned.Scan("08002b010203"); err != nil || scanned.Unwrap().String() != mac.String() {
+		t.Errorf("Expected scanned MAC, got %v (%v)", scanned, err)
+	}
+}
+
+type nativeRange struct {
+	Lower, Upper int
+}
+
+func (r nativeRange) Value() (driver.Value, error) {
+	return fmt.Sprintf("[%d,%d)", r.Lower, r.Upper), nil
+}
+
+func TestRegisterPassthrough(t *testing.T) {
+	r := nativeRange{Lower: 1, Upper: 5}
+	if v, err := Some(r).Value(); err != nil || v != "[1,5)" {
+		t.Errorf("Expected Value to be called before registering, got %v (%v)", v, err)
+	}
+
+	RegisterPassthrough(reflect.TypeOf(nativeRange{}))
+	defer func() {
+		passthrough.mu.Lock()
+		delete(passthrough.types, reflect.TypeOf(nativeRange{}))
+		passthrough.any.Store(len(passthrough.types) > 0)
+		passthrough.mu.Unlock()
+	}()
+
+	if v, err := Some(r).Value(); err != nil || v != r {
+		t.Errorf("Expected value to be passed through, got %v (%v)", v, err)
+	}
+	if v, err := None[nativeRange]().Value(); err != nil || v != nil {
+		t.Errorf("Expected nil for None, got %v (%v)", v, err)
+	}
+}
+
+type valuerString string
+
+func (s valuerString) Value() (driver.Value, error) {
+	return "valuer:" + string(s), nil
+}
+
+func TestPrimitiveValue(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		v        driver.Valuer
+		expected any
+	}{
+		{Some("a"), "a"},
+		{Some(int64(-1)), int64(-1)},
+		{Some(3), int64(3)},
+		{Some(int8(-8)), int64(-8)},
+		{Some(uint32(1 << 31)), int64(1 << 31)},
+		{Some(float32(1.5)), float64(1.5)},
+		{Some(2.5), 2.5},
+		{Some(true), true},
+		{Some(now), now},
+		{Some(valuerString("b")), "valuer:b"},
+	} {
+		if v, err := tc.v.Value(); err != nil || v != tc.expected {
+			t.Errorf("Expected %#v, got %#v (%v)", tc.expected, v, err)
+		}
+	}
+
+	if v, err := Some([]byte("raw")).Value(); err != nil || string(v.([]byte)) != "raw" {
+		t.Errorf("Expected raw bytes, got %#v (%v)", v, err)
+	}
+
+	for _, v := range []driver.Valuer{Some(0), Some(true), Some(int64(200))} {
+		if allocs := testing.AllocsPerRun(100, func() { _, _ = v.Value() }); allocs != 0 {
+			t.Errorf("Expected Value of %v not to allocate, got %v allocations", v, allocs)
+		}
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+	o := Some(int64(123456))
+	for i := 0; i < b.N; i++ {
+		_, _ = o.Value()
+	}
+}
+
+type fakeCursor struct{}
+
+func (fakeCursor) Columns() []string              { return []string{"id"} }
+func (fakeCursor) Close() error                   { return nil }
+func (fakeCursor) Next(dest []driver.Value) error { return io.EOF }
+
+type cursorColumns []string
+
+func (c *cursorColumns) Scan(src any) error {
+	*c = src.(driver.Rows).Columns()
+	return nil
+}
+
+func TestScanCursor(t *testing.T) {
+	var o Option[int]
+	if err := o.Scan(fakeCursor{}); !errors.Is(err, ErrCursorUnsupported) {
+		t.Errorf("Expected ErrCursorUnsupported, got %v", err)
+	}
+
+	var columns Option[cursorColumns]
+	if err := columns.Scan(fakeCursor{}); err != nil || len(columns.Unwrap()) != 1 {
+		t.Errorf("Expected cursor to be handled by Scanner, got %v (%v)", columns, err)
+	}
+}
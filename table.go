@@ -0,0 +1,175 @@
+package goption
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TableFormat is the layout of tables written by RenderTable.
+type TableFormat int
+
+const (
+	// TextTable aligns columns with spaces.
+	TextTable TableFormat = iota
+	// MarkdownTable writes a GitHub flavored Markdown table.
+	MarkdownTable
+)
+
+// TableOption configures RenderTable.
+type TableOption func(*tableConfig)
+
+type tableConfig struct {
+	format TableFormat
+	none   string
+}
+
+// WithTableFormat sets the layout of the table, TextTable by default.
+func WithTableFormat(format TableFormat) TableOption {
+	return func(c *tableConfig) {
+		c.format = format
+	}
+}
+
+// WithNonePlaceholder sets what empty options and nil pointers are shown as,
+// "-" by default. E.g. "NULL" or "".
+func WithNonePlaceholder(placeholder string) TableOption {
+	return func(c *tableConfig) {
+		c.none = placeholder
+	}
+}
+
+// RenderTable writes rows, a slice of structs or pointers to structs, to w
+// as a table with a column per field. Columns are named by table tags or
+// else by field name, fields tagged "-" are skipped and fields of embedded
+// structs are promoted. Times are formatted as RFC 3339, other values with
+// fmt.
+func RenderTable(w io.Writer, rows any, opts ...TableOption) error {
+	c := tableConfig{none: "-"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported table rows %T, must be a slice of structs", rows)
+	}
+	et := rv.Type().Elem()
+	if et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported table rows %T, must be a slice of structs", rows)
+	}
+
+	fields := cachedFields(et, "table", false)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+
+	cells := make([][]string, rv.Len())
+	for i := range cells {
+		row := reflect.Indirect(rv.Index(i))
+		cells[i] = make([]string, len(fields))
+		for j, f := range fields {
+			if !row.IsValid() {
+				cells[i][j] = c.none
+				continue
+			}
+			cells[i][j] = c.formatCell(fieldByIndex(row, f.index, false))
+		}
+	}
+
+	if c.format == MarkdownTable {
+		return writeMarkdownTable(w, header, cells)
+	}
+	return writeTextTable(w, header, cells)
+}
+
+// formatCell formats the value of a field.
+func (c *tableConfig) formatCell(v reflect.Value) string {
+	v = addressable(v)
+	for {
+		if !v.IsValid() {
+			return c.none
+		}
+		if opt, isOption := asReflectOption(v); isOption {
+			val, ok := opt.reflectGet()
+			if !ok {
+				return c.none
+			}
+			v = addressable(val)
+			continue
+		}
+		if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return c.none
+			}
+			v = addressable(v.Elem())
+			continue
+		}
+		break
+	}
+
+	if t, isTime := v.Interface().(time.Time); isTime {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func writeTextTable(w io.Writer, header []string, cells [][]string) error {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, cells...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range append([][]string{header}, cells...) {
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		b.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func writeMarkdownTable(w io.Writer, header []string, cells [][]string) error {
+	var b strings.Builder
+	writeRow := func(row []string) {
+		b.WriteByte('|')
+		for _, cell := range row {
+			b.WriteByte(' ')
+			b.WriteString(markdownEscaper.Replace(cell))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+
+	writeRow(header)
+	b.WriteByte('|')
+	for range header {
+		b.WriteString(" --- |")
+	}
+	b.WriteByte('\n')
+	for _, row := range cells {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,69 @@
+package goption
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type tableAudit struct {
+	UpdatedAt Option[time.Time] `table:"updated"`
+}
+
+type tableRow struct {
+	tableAudit
+	Name   string
+	Email  Option[string] `table:"email"`
+	Age    Option[int]
+	Parent *tableRow `table:"-"`
+	Note   *string
+}
+
+func TestRenderTable(t *testing.T) {
+	note := "a|b"
+	rows := []tableRow{
+		{Name: "ada", Email: Some("ada@example.com"), Age: Some(36), Note: &note,
+			tableAudit: tableAudit{UpdatedAt: Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))}},
+		{Name: "bob"},
+	}
+
+	var b strings.Builder
+	if err := RenderTable(&b, rows); err != nil {
+		t.Fatalf("Failed rendering: %s", err)
+	}
+	expected := "" +
+		"updated               Name  email            Age  Note\n" +
+		"2024-01-02T03:04:05Z  ada   ada@example.com  36   a|b\n" +
+		"-                     bob   -                -    -\n"
+	if b.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := RenderTable(&b, []*tableRow{&rows[1], nil}, WithTableFormat(MarkdownTable), WithNonePlaceholder("NULL")); err != nil {
+		t.Fatalf("Failed rendering: %s", err)
+	}
+	expected = "" +
+		"| updated | Name | email | Age | Note |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| NULL | bob | NULL | NULL | NULL |\n" +
+		"| NULL | NULL | NULL | NULL | NULL |\n"
+	if b.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	if err := RenderTable(&b, rows[:1], WithTableFormat(MarkdownTable)); err != nil || !strings.Contains(b.String(), `a\|b`) {
+		t.Errorf("Expected escaped pipe, got %s (%v)", b.String(), err)
+	}
+}
+
+func TestRenderTableErrors(t *testing.T) {
+	var b strings.Builder
+	if err := RenderTable(&b, tableRow{}); err == nil {
+		t.Errorf("Expected error for non-slice rows")
+	}
+	if err := RenderTable(&b, []int{1}); err == nil {
+		t.Errorf("Expected error for slice of non-structs")
+	}
+}
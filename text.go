@@ -0,0 +1,116 @@
+package goption
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/olachat/goption/convert"
+)
+
+// ErrNoneText is returned by StrictText.MarshalText for empty options.
+var ErrNoneText = errors.New("empty option has no text representation")
+
+// MarshalText implements encoding.TextMarshaler. Present values are encoded by
+// their MarshalText method, as is if T is a string type, or with strconv if
+// it's a boolean or number type. Empty options are encoded as empty text.
+// For string types this doesn't round trip: None and Some("") both encode as
+// empty text, which UnmarshalText decodes as Some(""). Use StrictText to
+// reject empty options instead, e.g. for map keys.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if !o.ok {
+		return []byte{}, nil
+	}
+
+	var v any = o.t
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+
+	if text, ok := convert.AppendBytes(nil, o.t); ok {
+		return text, nil
+	}
+
+	return nil, fmt.Errorf("unsupported text type %T", o.t)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text is decoded by the
+// UnmarshalText method of *T, as is if T is a string type, or with strconv
+// if it's a boolean or number type. Empty text decodes as an empty option,
+// unless T is a string type, whose empty text is the empty string.
+func (o *Option[T]) UnmarshalText(data []byte) error {
+	var t T
+	if len(data) == 0 && reflect.TypeOf(&t).Elem().Kind() != reflect.String {
+		*o = None[T]()
+		return nil
+	}
+
+	if u, ok := any(&t).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText(data); err != nil {
+			return err
+		}
+		*o = Some(t)
+		return nil
+	}
+
+	if err := parseText(reflect.ValueOf(&t).Elem(), string(data)); err != nil {
+		return err
+	}
+	*o = Some(t)
+	return nil
+}
+
+// parseText sets rv, a string, boolean or number, to the value of text.
+func parseText(rv reflect.Value, text string) error {
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported text type %s", rv.Type())
+	}
+
+	return nil
+}
+
+// StrictText is an Option whose MarshalText fails with ErrNoneText for empty
+// options rather than encoding them as empty text, e.g. for map keys where
+// an empty option would collide with Some("").
+type StrictText[T any] struct {
+	Option[T]
+}
+
+// MarshalText implements encoding.TextMarshaler like Option.MarshalText, but
+// fails for empty options.
+func (s StrictText[T]) MarshalText() ([]byte, error) {
+	if !s.ok {
+		return nil, ErrNoneText
+	}
+
+	return s.Option.MarshalText()
+}
@@ -0,0 +1,153 @@
+package goption
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTextMarshaling(t *testing.T) {
+	if text, err := Some(enumString("a")).MarshalText(); err != nil || string(text) != "a" {
+		t.Errorf("Expected named string text, got %q (%v)", text, err)
+	}
+	addr := netip.MustParseAddr("10.0.0.1")
+	if text, err := Some(addr).MarshalText(); err != nil || string(text) != "10.0.0.1" {
+		t.Errorf("Expected delegated text, got %q (%v)", text, err)
+	}
+	if text, err := None[string]().MarshalText(); err != nil || text == nil || len(text) != 0 {
+		t.Errorf("Expected empty text, got %q (%v)", text, err)
+	}
+	if _, err := (StrictText[string]{}).MarshalText(); !errors.Is(err, ErrNoneText) {
+		t.Errorf("Expected ErrNoneText, got %v", err)
+	}
+	if text, err := (StrictText[int]{Some(1)}).MarshalText(); err != nil || string(text) != "1" {
+		t.Errorf("Expected 1, got %q (%v)", text, err)
+	}
+	if _, err := Some(struct{}{}).MarshalText(); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+
+	var o Option[netip.Addr]
+	if err := o.UnmarshalText([]byte("::1")); err != nil || o.Unwrap() != netip.IPv6Loopback() {
+		t.Errorf("Expected delegated parsing, got %v (%v)", o, err)
+	}
+	if err := o.UnmarshalText([]byte("nope")); err == nil {
+		t.Errorf("Expected parse error")
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	m := map[Option[string]]int{Some("a"): 1, Some(""): 2}
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"":2,"a":1}` {
+		t.Errorf("Unexpected JSON: %s (%v)", data, err)
+	}
+
+	var decoded map[Option[string]]int
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, m) {
+		t.Errorf("Expected %v, got %v (%v)", m, decoded, err)
+	}
+
+	strict := map[StrictText[string]]int{{None[string]()}: 3}
+	if _, err := json.Marshal(strict); !errors.Is(err, ErrNoneText) {
+		t.Errorf("Expected ErrNoneText for None key, got %v", err)
+	}
+}
+
+func TestTextNone(t *testing.T) {
+	v := Some(4)
+	if err := v.UnmarshalText(nil); err != nil || v.Ok() {
+		t.Errorf("Expected empty text to decode as None, got %v (%v)", v, err)
+	}
+	s := None[string]()
+	if err := s.UnmarshalText([]byte{}); err != nil || !s.Ok() || s.Unwrap() != "" {
+		t.Errorf("Expected empty text to decode as Some(\"\") for strings, got %v (%v)", s, err)
+	}
+
+	text, err := None[string]().MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Fatalf("Expected None to encode as empty text, got %q (%v)", text, err)
+	}
+	if err := s.UnmarshalText(text); err != nil || s != Some("") {
+		t.Errorf("Expected None strings to round trip as Some(\"\"), got %v (%v)", s, err)
+	}
+	n := Some(4)
+	if text, err = None[int]().MarshalText(); err != nil {
+		t.Fatalf("Failed encoding None: %s", err)
+	}
+	if err := n.UnmarshalText(text); err != nil || n.Ok() {
+		t.Errorf("Expected None numbers to round trip as None, got %v (%v)", n, err)
+	}
+
+	var b strings.Builder
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("m", "field", None[int]())
+	if got := b.String(); strings.Contains(got, "ERROR") || !strings.Contains(got, `field=""`) {
+		t.Errorf("Expected None to log as empty text, got %q", got)
+	}
+}
+
+func TestTextPrimitives(t *testing.T) {
+	for _, tc := range []struct {
+		o        interface{ MarshalText() ([]byte, error) }
+		expected string
+	}{
+		{Some(true), "true"},
+		{Some(-42), "-42"},
+		{Some(uint8(200)), "200"},
+		{Some(1.5), "1.5"},
+		{Some(float32(0.1)), "0.1"},
+	} {
+		if text, err := tc.o.MarshalText(); err != nil || string(text) != tc.expected {
+			t.Errorf("Expected %q, got %q (%v)", tc.expected, text, err)
+		}
+	}
+
+	var b Option[bool]
+	if err := b.UnmarshalText([]byte("true")); err != nil || !b.Unwrap() {
+		t.Errorf("Expected true, got %v (%v)", b, err)
+	}
+	var i Option[int8]
+	if err := i.UnmarshalText([]byte("-8")); err != nil || i.Unwrap() != -8 {
+		t.Errorf("Expected -8, got %v (%v)", i, err)
+	}
+	if err := i.UnmarshalText([]byte("300")); err == nil {
+		t.Errorf("Expected range error, got %v", i)
+	}
+	var u Option[uint]
+	if err := u.UnmarshalText([]byte("-1")); err == nil {
+		t.Errorf("Expected syntax error, got %v", u)
+	}
+	var f Option[float64]
+	if err := f.UnmarshalText([]byte("2.25")); err != nil || f.Unwrap() != 2.25 {
+		t.Errorf("Expected 2.25, got %v (%v)", f, err)
+	}
+	var s Option[struct{}]
+	if err := s.UnmarshalText([]byte("{}")); err == nil {
+		t.Errorf("Expected error for unsupported type")
+	}
+}
+
+func TestJSONIntMapKeys(t *testing.T) {
+	m := map[Option[int]]string{Some(1): "a", Some(20): "b"}
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"1":"a","20":"b"}` {
+		t.Errorf("Unexpected JSON: %s (%v)", data, err)
+	}
+
+	var decoded map[Option[int]]string
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, m) {
+		t.Errorf("Expected %v, got %v (%v)", m, decoded, err)
+	}
+}
@@ -0,0 +1,45 @@
+package goption
+
+import (
+	"math"
+	"time"
+)
+
+// AddDur returns t plus d. The result is empty if either is empty or if the
+// addition overflows the range of time.Time.
+func AddDur(t Option[time.Time], d Option[time.Duration]) Option[time.Time] {
+	if !t.ok || !d.ok {
+		return None[time.Time]()
+	}
+
+	r := t.t.Add(d.t)
+	if (d.t > 0 && !r.After(t.t)) || (d.t < 0 && !r.Before(t.t)) {
+		return None[time.Time]()
+	}
+	return Some(r)
+}
+
+// Sub returns the duration a-b. The result is empty if either is empty or if
+// the difference doesn't fit in a time.Duration, where time.Time.Sub would
+// silently saturate.
+func Sub(a, b Option[time.Time]) Option[time.Duration] {
+	if !a.ok || !b.ok {
+		return None[time.Duration]()
+	}
+
+	d := a.t.Sub(b.t)
+	if (d == math.MaxInt64 || d == math.MinInt64) && !b.t.Add(d).Equal(a.t) {
+		return None[time.Duration]()
+	}
+	return Some(d)
+}
+
+// Since returns the time elapsed since t, like time.Since.
+func Since(t Option[time.Time]) Option[time.Duration] {
+	return Sub(Some(time.Now()), t)
+}
+
+// Until returns the duration until t, like time.Until.
+func Until(t Option[time.Time]) Option[time.Duration] {
+	return Sub(t, Some(time.Now()))
+}
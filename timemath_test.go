@@ -0,0 +1,75 @@
+package goption
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAddDur(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if r := AddDur(Some(start), Some(time.Hour)); !r.Unwrap().Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected %v, got %v", start.Add(time.Hour), r)
+	}
+	if r := AddDur(Some(start), Some(-time.Hour)); !r.Unwrap().Equal(start.Add(-time.Hour)) {
+		t.Errorf("Expected %v, got %v", start.Add(-time.Hour), r)
+	}
+	if r := AddDur(None[time.Time](), Some(time.Hour)); r.Ok() {
+		t.Errorf("Expected empty option, got %v", r)
+	}
+	if r := AddDur(Some(start), None[time.Duration]()); r.Ok() {
+		t.Errorf("Expected empty option, got %v", r)
+	}
+
+	latest := time.Unix(math.MaxInt64-62135596800, 999999999)
+	if r := AddDur(Some(latest), Some(time.Second)); r.Ok() {
+		t.Errorf("Expected overflow to be empty, got %v", r)
+	}
+	if r := AddDur(Some(latest.Add(-time.Second)), Some(time.Second)); !r.Ok() {
+		t.Errorf("Expected the latest time, got %v", r)
+	}
+}
+
+func TestSub(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+
+	if d := Sub(Some(end), Some(start)); d.Unwrap() != 90*time.Minute {
+		t.Errorf("Expected 1h30m, got %v", d)
+	}
+	if d := Sub(Some(start), Some(end)); d.Unwrap() != -90*time.Minute {
+		t.Errorf("Expected -1h30m, got %v", d)
+	}
+	if d := Sub(Some(end), None[time.Time]()); d.Ok() {
+		t.Errorf("Expected empty option, got %v", d)
+	}
+
+	far := start.AddDate(300, 0, 0)
+	if d := Sub(Some(far), Some(start)); d.Ok() {
+		t.Errorf("Expected overflow to be empty, got %v", d)
+	}
+	if d := Sub(Some(start), Some(far)); d.Ok() {
+		t.Errorf("Expected underflow to be empty, got %v", d)
+	}
+	exact := start.Add(math.MaxInt64)
+	if d := Sub(Some(exact), Some(start)); d.Unwrap() != math.MaxInt64 {
+		t.Errorf("Expected the maximum duration, got %v", d)
+	}
+}
+
+func TestSinceUntil(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	if d := Since(Some(past)); d.Unwrap() < time.Hour {
+		t.Errorf("Expected at least an hour, got %v", d)
+	}
+	if d := Until(Some(past)); d.Unwrap() > -time.Hour {
+		t.Errorf("Expected at most minus an hour, got %v", d)
+	}
+	if d := Since(None[time.Time]()); d.Ok() {
+		t.Errorf("Expected empty option, got %v", d)
+	}
+	if d := Until(None[time.Time]()); d.Ok() {
+		t.Errorf("Expected empty option, got %v", d)
+	}
+}
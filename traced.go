@@ -0,0 +1,147 @@
+package goption
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoReason is the reason of empty Traced values which weren't given one.
+var ErrNoReason = errors.New("no value")
+
+// Traced is an Option which, when empty, records why. Reasons are carried
+// through Map, FlatMap and Filter steps so the end of a chain can explain an
+// empty result.
+type Traced[T any] struct {
+	opt    Option[T]
+	reason error
+}
+
+// WithReason returns a Traced wrapping o. If o is empty its reason is reason,
+// or ErrNoReason if reason is nil; otherwise reason is ignored.
+func WithReason[T any](o Option[T], reason error) Traced[T] {
+	if o.ok {
+		return Traced[T]{opt: o}
+	}
+	if reason == nil {
+		reason = ErrNoReason
+	}
+
+	return Traced[T]{opt: o, reason: reason}
+}
+
+// TracedSome returns a present Traced value.
+func TracedSome[T any](t T) Traced[T] {
+	return Traced[T]{opt: Some(t)}
+}
+
+// TracedNone returns an empty Traced value because of reason.
+func TracedNone[T any](reason error) Traced[T] {
+	return WithReason(None[T](), reason)
+}
+
+// Option returns the underlying optional value.
+func (t Traced[T]) Option() Option[T] {
+	return t.opt
+}
+
+// Ok returns if the value is present.
+func (t Traced[T]) Ok() bool {
+	return t.opt.ok
+}
+
+// Get returns the underlying value and, if it's not present, why.
+func (t Traced[T]) Get() (T, error) {
+	return t.opt.t, t.Reason()
+}
+
+// Reason returns why the value is empty, or nil if it's present.
+func (t Traced[T]) Reason() error {
+	if t.opt.ok {
+		return nil
+	}
+	if t.reason == nil {
+		return ErrNoReason
+	}
+
+	return t.reason
+}
+
+// Unwrap forcefully unwraps the value.
+// If the value is not present this function panics with its reason.
+func (t Traced[T]) Unwrap() T {
+	if !t.opt.ok {
+		panic(fmt.Sprintf("Unwrapped empty optional: %s", t.Reason()))
+	}
+
+	return t.opt.t
+}
+
+// UnwrapOr unwraps the value if it's present, otherwise it returns def.
+func (t Traced[T]) UnwrapOr(def T) T {
+	return t.opt.UnwrapOr(def)
+}
+
+// Filter empties t because of reason unless its value satisfies pred.
+func (t Traced[T]) Filter(pred func(T) bool, reason error) Traced[T] {
+	if !t.opt.ok || pred(t.opt.t) {
+		return t
+	}
+
+	return TracedNone[T](reason)
+}
+
+// Or returns t if its value is present, otherwise other. If both are empty
+// the reason joins both reasons.
+func (t Traced[T]) Or(other Traced[T]) Traced[T] {
+	if t.opt.ok {
+		return t
+	}
+	if other.opt.ok {
+		return other
+	}
+
+	return TracedNone[T](errors.Join(t.Reason(), other.Reason()))
+}
+
+// String implements fmt.Stringer
+func (t Traced[T]) String() string {
+	if !t.opt.ok {
+		return fmt.Sprintf("null (%s)", t.Reason())
+	}
+
+	return t.opt.String()
+}
+
+// TracedMap applies f to the value of t if it's present, keeping the reason
+// otherwise.
+func TracedMap[T, U any](t Traced[T], f func(T) U) Traced[U] {
+	if !t.opt.ok {
+		return TracedNone[U](t.Reason())
+	}
+
+	return TracedSome(f(t.opt.t))
+}
+
+// TracedFlatMap applies f to the value of t if it's present. If f returns an
+// empty option the result is empty because of reason.
+func TracedFlatMap[T, U any](t Traced[T], f func(T) Option[U], reason error) Traced[U] {
+	if !t.opt.ok {
+		return TracedNone[U](t.Reason())
+	}
+
+	return WithReason(f(t.opt.t), reason)
+}
+
+// TracedAndThen applies f to the value of t if it's present. If f fails the
+// result is empty because of its error.
+func TracedAndThen[T, U any](t Traced[T], f func(T) (U, error)) Traced[U] {
+	if !t.opt.ok {
+		return TracedNone[U](t.Reason())
+	}
+
+	u, err := f(t.opt.t)
+	if err != nil {
+		return TracedNone[U](err)
+	}
+	return TracedSome(u)
+}
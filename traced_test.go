@@ -0,0 +1,67 @@
+package goption
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+var (
+	errMissingHeader = errors.New("missing header")
+	errNegative      = errors.New("negative")
+	errNoUser        = errors.New("no such user")
+)
+
+func TestTracedChain(t *testing.T) {
+	lookup := func(id int) Option[string] {
+		if id == 1 {
+			return Some("ada")
+		}
+		return None[string]()
+	}
+	chain := func(header Option[string]) Traced[string] {
+		id := TracedAndThen(WithReason(header, errMissingHeader), strconv.Atoi)
+		id = id.Filter(func(i int) bool { return i >= 0 }, errNegative)
+		return TracedFlatMap(id, lookup, errNoUser)
+	}
+
+	if name, err := chain(Some("1")).Get(); err != nil || name != "ada" {
+		t.Errorf("Expected ada, got %q (%v)", name, err)
+	}
+
+	for header, expected := range map[string]error{"-1": errNegative, "2": errNoUser} {
+		if err := chain(Some(header)).Reason(); err != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, header, err)
+		}
+	}
+	if err := chain(None[string]()).Reason(); err != errMissingHeader {
+		t.Errorf("Expected missing header, got %v", err)
+	}
+
+	var numErr *strconv.NumError
+	if err := chain(Some("x")).Reason(); !errors.As(err, &numErr) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+}
+
+func TestTracedOr(t *testing.T) {
+	a, b := TracedNone[int](errNegative), TracedNone[int](errNoUser)
+	joined := a.Or(b).Reason()
+	if !errors.Is(joined, errNegative) || !errors.Is(joined, errNoUser) {
+		t.Errorf("Expected both reasons, got %v", joined)
+	}
+
+	if v := a.Or(TracedSome(3)); v.Unwrap() != 3 || v.Reason() != nil {
+		t.Errorf("Expected fallback value, got %v", v)
+	}
+	if s := TracedMap(a, strconv.Itoa).String(); s != "null (negative)" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+	if r := WithReason(None[int](), nil).Reason(); r != ErrNoReason {
+		t.Errorf("Expected default reason, got %v", r)
+	}
+	var zero Traced[int]
+	if zero.Reason() != ErrNoReason {
+		t.Errorf("Expected default reason for zero value, got %v", zero.Reason())
+	}
+}
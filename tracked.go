@@ -0,0 +1,159 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// Tracked is an Option which remembers whether it was modified since it was
+// loaded, so only changed columns need to be written back.
+type Tracked[T any] struct {
+	opt   Option[T]
+	dirty bool
+}
+
+// Track returns a clean Tracked holding o, as if it was just loaded.
+func Track[T any](o Option[T]) Tracked[T] {
+	return Tracked[T]{opt: o}
+}
+
+// Option returns the underlying optional value.
+func (t Tracked[T]) Option() Option[T] {
+	return t.opt
+}
+
+// Get returns the underlying value and a boolean indicating if it's present.
+func (t Tracked[T]) Get() (T, bool) {
+	return t.opt.Get()
+}
+
+// Ok returns if the value is present.
+func (t Tracked[T]) Ok() bool {
+	return t.opt.ok
+}
+
+// Dirty reports whether t was modified since it was loaded or marked clean.
+func (t Tracked[T]) Dirty() bool {
+	return t.dirty
+}
+
+// Set makes the value present and marks t dirty.
+func (t *Tracked[T]) Set(v T) {
+	t.opt, t.dirty = Some(v), true
+}
+
+// SetOption replaces the value with o and marks t dirty.
+func (t *Tracked[T]) SetOption(o Option[T]) {
+	t.opt, t.dirty = o, true
+}
+
+// Clear empties the value and marks t dirty.
+func (t *Tracked[T]) Clear() {
+	t.opt, t.dirty = None[T](), true
+}
+
+// MarkClean forgets that t was modified, e.g. after it was written.
+func (t *Tracked[T]) MarkClean() {
+	t.dirty = false
+}
+
+// Scan implements sql.Scanner. Scanned values are clean.
+func (t *Tracked[T]) Scan(src any) error {
+	t.dirty = false
+	return t.opt.Scan(src)
+}
+
+// Value implements driver.Valuer
+func (t Tracked[T]) Value() (driver.Value, error) {
+	return t.opt.Value()
+}
+
+// String implements fmt.Stringer
+func (t Tracked[T]) String() string {
+	if t.dirty {
+		return t.opt.String() + " (dirty)"
+	}
+
+	return t.opt.String()
+}
+
+// dirtyTracker is implemented by *Tracked[T].
+type dirtyTracker interface {
+	driver.Valuer
+	Dirty() bool
+	MarkClean()
+}
+
+var dirtyTrackerType = reflect.TypeOf((*dirtyTracker)(nil)).Elem()
+
+// DirtyColumn is a modified Tracked field of a struct.
+type DirtyColumn struct {
+	Column string
+	Value  driver.Value
+}
+
+// DirtyColumns returns the modified Tracked fields of v, a struct or pointer
+// to a struct, in field order. Columns are named like ScanStruct names them.
+func DirtyColumns(v any) ([]DirtyColumn, error) {
+	rv, err := trackedStruct(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []DirtyColumn
+	for _, f := range cachedFields(rv.Type(), "db", true) {
+		tracker, ok := trackerOf(rv, f)
+		if !ok || !tracker.Dirty() {
+			continue
+		}
+
+		value, err := tracker.Value()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		columns = append(columns, DirtyColumn{Column: f.name, Value: value})
+	}
+
+	return columns, nil
+}
+
+// MarkClean marks every Tracked field of v, a pointer to a struct, clean.
+// Call it once the changes returned by DirtyColumns are committed.
+func MarkClean(v any) error {
+	if rv := reflect.ValueOf(v); rv.Kind() != reflect.Pointer {
+		return fmt.Errorf("unsupported type %T, must be a pointer to a struct", v)
+	}
+	rv, err := trackedStruct(v)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range cachedFields(rv.Type(), "db", true) {
+		if tracker, ok := trackerOf(rv, f); ok {
+			tracker.MarkClean()
+		}
+	}
+	return nil
+}
+
+func trackedStruct(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("unsupported type %T, must be a struct", v)
+	}
+
+	return addressable(rv), nil
+}
+
+func trackerOf(rv reflect.Value, f structField) (dirtyTracker, bool) {
+	fv := fieldByIndex(rv, f.index, false)
+	if !fv.IsValid() || !fv.CanAddr() || !reflect.PointerTo(fv.Type()).Implements(dirtyTrackerType) {
+		return nil, false
+	}
+
+	return fv.Addr().Interface().(dirtyTracker), true
+}
@@ -0,0 +1,63 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type trackedUser struct {
+	ID    int64           `db:"id"`
+	Name  Tracked[string] `db:"name"`
+	Email Tracked[string] `db:"email"`
+	Age   Tracked[int]    `db:"age"`
+}
+
+func TestTracked(t *testing.T) {
+	var name Tracked[string]
+	if err := name.Scan("ada"); err != nil || name.Dirty() || name.Option().Unwrap() != "ada" {
+		t.Errorf("Expected clean scanned value, got %v (%v)", name, err)
+	}
+
+	name.Set("grace")
+	if !name.Dirty() || name.String() != "grace (dirty)" {
+		t.Errorf("Expected dirty value, got %v", name)
+	}
+
+	name.MarkClean()
+	name.Clear()
+	if !name.Dirty() || name.Ok() {
+		t.Errorf("Expected dirty empty value, got %v", name)
+	}
+	if v, err := name.Value(); err != nil || v != nil {
+		t.Errorf("Expected NULL value, got %v (%v)", v, err)
+	}
+}
+
+func TestDirtyColumns(t *testing.T) {
+	u := trackedUser{ID: 1, Name: Track(Some("ada")), Email: Track(Some("a@x")), Age: Track(None[int]())}
+	if columns, err := DirtyColumns(u); err != nil || len(columns) != 0 {
+		t.Errorf("Expected no dirty columns, got %v (%v)", columns, err)
+	}
+
+	u.Email.Clear()
+	u.Age.Set(36)
+	columns, err := DirtyColumns(&u)
+	if err != nil {
+		t.Fatalf("Failed collecting dirty columns: %s", err)
+	}
+	expected := []DirtyColumn{{Column: "email", Value: nil}, {Column: "age", Value: driver.Value(int64(36))}}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected %v, got %v", expected, columns)
+	}
+
+	if err := MarkClean(&u); err != nil {
+		t.Fatalf("Failed marking clean: %s", err)
+	}
+	if columns, _ := DirtyColumns(u); len(columns) != 0 || u.Age.Option().Unwrap() != 36 {
+		t.Errorf("Expected clean values to be kept, got %v", columns)
+	}
+	if err := MarkClean(u); err == nil {
+		t.Errorf("Expected error marking a copy clean")
+	}
+}
@@ -0,0 +1,77 @@
+package goption
+
+import (
+	"encoding/json"
+)
+
+// Undefinable is an Option which also records whether it was set at all. In
+// JSON it distinguishes a missing field, which leaves it undefined, from an
+// explicit null, which defines it as empty, as JSON Merge Patch requires.
+type Undefinable[T any] struct {
+	opt Option[T]
+	set bool
+}
+
+// Defined returns an Undefinable which was set to o.
+func Defined[T any](o Option[T]) Undefinable[T] {
+	return Undefinable[T]{opt: o, set: true}
+}
+
+// Undefined returns an Undefinable which wasn't set.
+func Undefined[T any]() Undefinable[T] {
+	return Undefinable[T]{}
+}
+
+// IsSet reports whether u was set, possibly to an empty option.
+func (u Undefinable[T]) IsSet() bool {
+	return u.set
+}
+
+// Option returns the value u was set to, or an empty option if it wasn't set.
+func (u Undefinable[T]) Option() Option[T] {
+	return u.opt
+}
+
+// Ok returns if u was set to a present value.
+func (u Undefinable[T]) Ok() bool {
+	return u.opt.ok
+}
+
+// Get returns the underlying value and a boolean indicating if it's present.
+func (u Undefinable[T]) Get() (T, bool) {
+	return u.opt.Get()
+}
+
+// IsZero reports whether u wasn't set, so fields tagged omitzero are omitted
+// when undefined but kept when explicitly null. encoding/json honors omitzero
+// since Go 1.24 and ignores it before.
+func (u Undefinable[T]) IsZero() bool {
+	return !u.set
+}
+
+// MarshalJSON marshals the value u was set to. Undefined values marshal as
+// null; tag fields omitzero to omit them instead, with Go 1.24 or later.
+func (u Undefinable[T]) MarshalJSON() ([]byte, error) {
+	return u.opt.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals data and marks u as set. encoding/json only calls
+// it for fields present in the input, missing fields stay undefined.
+func (u *Undefinable[T]) UnmarshalJSON(data []byte) error {
+	var o Option[T]
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+
+	*u = Defined(o)
+	return nil
+}
+
+// String implements fmt.Stringer
+func (u Undefinable[T]) String() string {
+	if !u.set {
+		return "undefined"
+	}
+
+	return u.opt.String()
+}
@@ -0,0 +1,17 @@
+//go:build go1.24
+
+package goption
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// encoding/json honors omitzero since Go 1.24.
+func TestUndefinableOmitZeroJSON(t *testing.T) {
+	p := mergePatch{Name: Defined(Some("ada")), Email: Defined(None[string]())}
+	encoded, err := json.Marshal(p)
+	if err != nil || string(encoded) != `{"name":"ada","email":null}` {
+		t.Errorf("Expected undefined fields to be omitted, got %s (%v)", encoded, err)
+	}
+}
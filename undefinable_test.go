@@ -0,0 +1,45 @@
+package goption
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type mergePatch struct {
+	Name  Undefinable[string] `json:"name,omitzero"`
+	Email Undefinable[string] `json:"email,omitzero"`
+	Age   Undefinable[int]    `json:"age,omitzero"`
+}
+
+func TestUndefinableJSON(t *testing.T) {
+	var p mergePatch
+	if err := json.Unmarshal([]byte(`{"name":"ada","email":null}`), &p); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+
+	if !p.Name.IsSet() || p.Name.Option().Unwrap() != "ada" {
+		t.Errorf("Expected name to be set, got %v", p.Name)
+	}
+	if !p.Email.IsSet() || p.Email.Ok() {
+		t.Errorf("Expected email to be set to null, got %v", p.Email)
+	}
+	if p.Age.IsSet() || p.Age.String() != "undefined" {
+		t.Errorf("Expected age to be undefined, got %v", p.Age)
+	}
+
+	if err := json.Unmarshal([]byte(`{"age":"x"}`), &p); err == nil {
+		t.Errorf("Expected error for invalid value")
+	}
+}
+
+func TestUndefinableConstructors(t *testing.T) {
+	if u := Defined(None[int]()); !u.IsSet() || u.Ok() || u.IsZero() {
+		t.Errorf("Expected defined empty value, got %v", u)
+	}
+	if u := Defined(Some(2)); u.String() != "2" {
+		t.Errorf("Unexpected string: %s", u)
+	}
+	if u := Undefined[int](); u.IsSet() || !u.IsZero() {
+		t.Errorf("Expected undefined value, got %v", u)
+	}
+}
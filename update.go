@@ -0,0 +1,167 @@
+package goption
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrConflict is returned by UpdateOptimistic if no row matched the key and
+// version, because the row was changed or deleted concurrently.
+var ErrConflict = errors.New("optimistic update conflict")
+
+// Execer is the part of *sql.DB, *sql.Conn and *sql.Tx used by
+// UpdateOptimistic.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// UpdateOption configures UpdateOptimistic.
+type UpdateOption func(*updateConfig)
+
+type updateConfig struct {
+	versionColumn string
+	retries       int
+	refresh       func(ctx context.Context) (any, Option[int64], error)
+}
+
+// WithVersionColumn sets the column holding the version, "version" by
+// default.
+func WithVersionColumn(column string) UpdateOption {
+	return func(c *updateConfig) {
+		c.versionColumn = column
+	}
+}
+
+// WithConflictRetry retries conflicting updates up to retries times. Before
+// each retry refresh is called to reload the row and return the patch to
+// apply to it along with its current version.
+func WithConflictRetry(retries int, refresh func(ctx context.Context) (patch any, version Option[int64], err error)) UpdateOption {
+	return func(c *updateConfig) {
+		c.retries, c.refresh = retries, refresh
+	}
+}
+
+// UpdateOptimistic updates the row of table matching key with patch, a
+// struct or pointer to a struct, if the row is still at version, and returns
+// the version it was bumped to. Columns are named by db tags or else by field
+// name. Empty Option fields and undefined Undefinable fields of patch are
+// left unchanged, Undefinable fields defined as empty are set to NULL and all
+// other fields are set. An empty version matches rows whose version is NULL,
+// like WhereEq.
+//
+// Queries use "?" placeholders. If no row matches, which can't be told apart
+// from the row not existing, ErrConflict is returned or the update is retried
+// as configured by WithConflictRetry.
+func UpdateOptimistic(ctx context.Context, db Execer, table string, key map[string]any, patch any, version Option[int64], opts ...UpdateOption) (int64, error) {
+	c := updateConfig{versionColumn: "version"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	for attempt := 0; ; attempt++ {
+		query, args, err := buildUpdate(table, key, patch, version, c.versionColumn)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if n > 0 {
+			return version.UnwrapOr(0) + 1, nil
+		}
+
+		if attempt >= c.retries || c.refresh == nil {
+			return 0, ErrConflict
+		}
+		if patch, version, err = c.refresh(ctx); err != nil {
+			return 0, fmt.Errorf("refreshing after conflict: %w", err)
+		}
+	}
+}
+
+// buildUpdate returns the UPDATE statement of UpdateOptimistic.
+func buildUpdate(table string, key map[string]any, patch any, version Option[int64], versionColumn string) (string, []any, error) {
+	rv := reflect.Indirect(reflect.ValueOf(patch))
+	if rv.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("unsupported patch %T, must be a struct", patch)
+	}
+	if len(key) == 0 {
+		return "", nil, errors.New("update key is empty")
+	}
+	rv = addressable(rv)
+
+	var set []string
+	var args []any
+	for _, f := range cachedFields(rv.Type(), "db", false) {
+		if f.name == versionColumn {
+			continue
+		}
+		fv := fieldByIndex(rv, f.index, false)
+		if !fv.IsValid() {
+			continue
+		}
+
+		arg, ok := patchArg(fv)
+		if !ok {
+			continue
+		}
+		set = append(set, f.name+" = ?")
+		args = append(args, arg)
+	}
+	set = append(set, versionColumn+" = ?")
+	args = append(args, version.UnwrapOr(0)+1)
+
+	columns := make([]string, 0, len(key))
+	for column := range key {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	where := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		where = append(where, column+" = ?")
+		args = append(args, key[column])
+	}
+	cond, versionArgs := WhereEq(versionColumn, version)
+	where = append(where, cond)
+	args = append(args, versionArgs...)
+
+	query := "UPDATE " + table + " SET " + strings.Join(set, ", ") + " WHERE " + strings.Join(where, " AND ")
+	return query, args, nil
+}
+
+// patchArg returns the argument the field fv of a patch is set to, or false
+// if it's left unchanged.
+func patchArg(fv reflect.Value) (any, bool) {
+	if opt, isOption := asReflectOption(fv); isOption {
+		if _, ok := opt.reflectGet(); !ok {
+			return nil, false
+		}
+		return fv.Interface(), true
+	}
+	if u, isUndefinable := fv.Interface().(patchField); isUndefinable {
+		return u.patchArg()
+	}
+
+	return fv.Interface(), true
+}
+
+// patchField is implemented by Undefinable.
+type patchField interface {
+	patchArg() (any, bool)
+}
+
+func (u Undefinable[T]) patchArg() (any, bool) {
+	return u.opt, u.set
+}
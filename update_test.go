@@ -0,0 +1,103 @@
+package goption
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type execCall struct {
+	query string
+	args  []any
+}
+
+// fakeExecer records statements and reports affected rows from a script.
+type fakeExecer struct {
+	calls    []execCall
+	affected []int64
+}
+
+func (e *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	n := e.affected[len(e.calls)]
+	e.calls = append(e.calls, execCall{query: query, args: args})
+	return driverResult(n), nil
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) { return 0, errors.New("not supported") }
+func (r driverResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+type userPatch struct {
+	Name     Option[string]      `db:"name"`
+	Email    Undefinable[string] `db:"email"`
+	Nickname Undefinable[string] `db:"nickname"`
+	Age      Option[int]         `db:"age"`
+	Version  Option[int64]       `db:"version"`
+}
+
+func TestUpdateOptimistic(t *testing.T) {
+	db := &fakeExecer{affected: []int64{1}}
+	patch := userPatch{Name: Some("ada"), Email: Defined(None[string]())}
+
+	version, err := UpdateOptimistic(context.Background(), db, "users", map[string]any{"tenant": "t", "id": 1}, patch, Some(int64(3)))
+	if err != nil || version != 4 {
+		t.Fatalf("Expected version 4, got %d (%v)", version, err)
+	}
+
+	call := db.calls[0]
+	if want := "UPDATE users SET name = ?, email = ?, version = ? WHERE id = ? AND tenant = ? AND version = ?"; call.query != want {
+		t.Errorf("Expected %q, got %q", want, call.query)
+	}
+	if want := []any{Some("ada"), None[string](), int64(4), 1, "t", int64(3)}; !reflect.DeepEqual(call.args, want) {
+		t.Errorf("Expected args %v, got %v", want, call.args)
+	}
+}
+
+func TestUpdateOptimisticNullVersion(t *testing.T) {
+	db := &fakeExecer{affected: []int64{1}}
+	version, err := UpdateOptimistic(context.Background(), db, "users", map[string]any{"id": 1}, &userPatch{Age: Some(3)}, None[int64](),
+		WithVersionColumn("rev"))
+	if err != nil || version != 1 {
+		t.Fatalf("Expected version 1, got %d (%v)", version, err)
+	}
+	if want := "UPDATE users SET age = ?, rev = ? WHERE id = ? AND rev IS NULL"; db.calls[0].query != want {
+		t.Errorf("Expected %q, got %q", want, db.calls[0].query)
+	}
+}
+
+func TestUpdateOptimisticConflict(t *testing.T) {
+	db := &fakeExecer{affected: []int64{0, 0, 1}}
+	key := map[string]any{"id": 1}
+	if _, err := UpdateOptimistic(context.Background(), db, "users", key, userPatch{}, Some(int64(1))); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict, got %v", err)
+	}
+
+	db.calls = nil
+	current := int64(5)
+	refresh := func(context.Context) (any, Option[int64], error) {
+		current++
+		return userPatch{Name: Some("bob")}, Some(current), nil
+	}
+	version, err := UpdateOptimistic(context.Background(), db, "users", key, userPatch{}, Some(int64(1)), WithConflictRetry(2, refresh))
+	if err != nil || version != 8 || len(db.calls) != 3 {
+		t.Errorf("Expected version 8 after 3 attempts, got %d after %d (%v)", version, len(db.calls), err)
+	}
+
+	db = &fakeExecer{affected: []int64{0, 0}}
+	if _, err := UpdateOptimistic(context.Background(), db, "users", key, userPatch{}, Some(int64(1)), WithConflictRetry(1, refresh)); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict after retries, got %v", err)
+	}
+}
+
+func TestUpdateOptimisticErrors(t *testing.T) {
+	db := &fakeExecer{}
+	if _, err := UpdateOptimistic(context.Background(), db, "users", map[string]any{"id": 1}, 1, None[int64]()); err == nil {
+		t.Errorf("Expected error for non-struct patch")
+	}
+	if _, err := UpdateOptimistic(context.Background(), db, "users", nil, userPatch{}, None[int64]()); err == nil {
+		t.Errorf("Expected error for empty key")
+	}
+}
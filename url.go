@@ -0,0 +1,88 @@
+package goption
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// QueryValue is an optional query parameter value. Every Option implements
+// it, present values are formatted by Option.String.
+type QueryValue interface {
+	Ok() bool
+	String() string
+}
+
+// URLBuilder builds URLs from optional path segments and query parameters.
+// The first error encountered is reported by Build.
+type URLBuilder struct {
+	base     *url.URL
+	segments []string
+	query    url.Values
+	err      error
+}
+
+// NewURLBuilder returns a URLBuilder extending base.
+func NewURLBuilder(base string) *URLBuilder {
+	u, err := url.Parse(base)
+	b := &URLBuilder{base: u, err: err}
+	if err == nil {
+		b.query = u.Query()
+	}
+
+	return b
+}
+
+// Path appends segments to the path. Segments are escaped and must not be
+// empty, "." or "..", which would otherwise change the path of the base URL.
+func (b *URLBuilder) Path(segments ...string) *URLBuilder {
+	for _, segment := range segments {
+		switch {
+		case b.err != nil:
+		case segment == "":
+			b.err = fmt.Errorf("empty path segment after %q", strings.Join(b.segments, "/"))
+		case segment == "." || segment == "..":
+			b.err = fmt.Errorf("dot path segment %q after %q", segment, strings.Join(b.segments, "/"))
+		}
+		b.segments = append(b.segments, segment)
+	}
+
+	return b
+}
+
+// OptionalPath appends segment to the path if it's present.
+func (b *URLBuilder) OptionalPath(segment Option[string]) *URLBuilder {
+	if !segment.ok {
+		return b
+	}
+
+	return b.Path(segment.t)
+}
+
+// Query adds the query parameter key if value is present.
+func (b *URLBuilder) Query(key string, value QueryValue) *URLBuilder {
+	if b.query != nil && value.Ok() {
+		b.query.Add(key, value.String())
+	}
+
+	return b
+}
+
+// Build returns the URL, or the first error encountered while building it.
+func (b *URLBuilder) Build() (*url.URL, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	u := *b.base
+	if len(b.segments) > 0 {
+		escaped := make([]string, len(b.segments))
+		for i, segment := range b.segments {
+			escaped[i] = url.PathEscape(segment)
+		}
+		u = *u.JoinPath(escaped...)
+	}
+	u.RawQuery = b.query.Encode()
+
+	return &u, nil
+}
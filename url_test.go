@@ -0,0 +1,49 @@
+package goption
+
+import (
+	"testing"
+)
+
+func TestURLBuilder(t *testing.T) {
+	u, err := NewURLBuilder("https://api.example.com/v1?key=abc").
+		Path("users", "a/b").
+		OptionalPath(None[string]()).
+		OptionalPath(Some("posts")).
+		Query("limit", Some(10)).
+		Query("cursor", None[string]()).
+		Query("active", Some(true)).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed building URL: %s", err)
+	}
+
+	if str := u.String(); str != "https://api.example.com/v1/users/a%2Fb/posts?active=true&key=abc&limit=10" {
+		t.Errorf("Unexpected URL: %s", str)
+	}
+}
+
+func TestURLBuilderErrors(t *testing.T) {
+	if _, err := NewURLBuilder("https://api.example.com").Path("users", "").Build(); err == nil {
+		t.Errorf("Expected error for empty path segment")
+	}
+
+	if _, err := NewURLBuilder("https://api.example.com").OptionalPath(Some("")).Build(); err == nil {
+		t.Errorf("Expected error for empty optional path segment")
+	}
+
+	for _, segment := range []string{".", ".."} {
+		if u, err := NewURLBuilder("https://api.example.com/v1/users").Path(segment, "admin").Build(); err == nil {
+			t.Errorf("Expected error for %q path segment, got %s", segment, u)
+		}
+		if u, err := NewURLBuilder("https://api.example.com/v1").OptionalPath(Some(segment)).Build(); err == nil {
+			t.Errorf("Expected error for %q optional path segment, got %s", segment, u)
+		}
+	}
+	if u, err := NewURLBuilder("https://api.example.com/v1").Path("..a", "b.").Build(); err != nil || u.Path != "/v1/..a/b." {
+		t.Errorf("Expected segments with dots to be kept, got %v (%v)", u, err)
+	}
+
+	if _, err := NewURLBuilder("://bad").Query("a", Some(1)).Build(); err == nil {
+		t.Errorf("Expected error for invalid base URL")
+	}
+}
@@ -0,0 +1,200 @@
+package goption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigSource loads the current state of a configuration, keyed like
+// DecodeMap expects.
+type ConfigSource interface {
+	Load() (map[string]any, error)
+}
+
+// ConfigSourceFunc adapts a function to a ConfigSource.
+type ConfigSourceFunc func() (map[string]any, error)
+
+// Load implements ConfigSource
+func (f ConfigSourceFunc) Load() (map[string]any, error) {
+	return f()
+}
+
+// FileSource returns a ConfigSource reading the JSON object in the file at
+// path.
+func FileSource(path string) ConfigSource {
+	return ConfigSourceFunc(func() (map[string]any, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", path, err)
+		}
+		return m, nil
+	})
+}
+
+// EnvSource returns a ConfigSource reading the environment variables starting
+// with prefix. Keys are the rest of the variable name in lower case, so with
+// prefix "APP_" the variable APP_MAX_CONNS sets the field named max_conns.
+func EnvSource(prefix string) ConfigSource {
+	return ConfigSourceFunc(func() (map[string]any, error) {
+		m := make(map[string]any)
+		for _, kv := range os.Environ() {
+			key, value, _ := strings.Cut(kv, "=")
+			if name, ok := strings.CutPrefix(key, prefix); ok && name != "" {
+				m[strings.ToLower(name)] = value
+			}
+		}
+		return m, nil
+	})
+}
+
+// FieldChange describes how a top level field of a configuration changed.
+// Old and New are empty when the field was or became None.
+type FieldChange struct {
+	Field string
+	Old   Option[any]
+	New   Option[any]
+}
+
+// ConfigChange is what Watcher subscribers are notified with.
+type ConfigChange[T any] struct {
+	Config  T
+	Changes []FieldChange
+}
+
+// Watcher keeps a struct of Options decoded from a ConfigSource up to date
+// and notifies subscribers of the fields which changed on every reload.
+// It's safe for concurrent use.
+type Watcher[T any] struct {
+	source ConfigSource
+	opts   []MapOption
+	state  *ObservableOption[ConfigChange[T]]
+	reload sync.Mutex
+}
+
+// NewWatcher returns a Watcher for source, having loaded it once. T must be a
+// struct; opts are passed to DecodeMap.
+func NewWatcher[T any](source ConfigSource, opts ...MapOption) (*Watcher[T], error) {
+	w := &Watcher[T]{source: source, opts: opts}
+
+	cfg, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.state = NewObservable(Some(ConfigChange[T]{Config: cfg}))
+
+	return w, nil
+}
+
+// Current returns the most recently loaded configuration.
+func (w *Watcher[T]) Current() T {
+	return w.state.Get().Unwrap().Config
+}
+
+// Subscribe registers f to be called after every reload which changed the
+// configuration, in the order of the reloads. Like for
+// ObservableOption.Subscribe, f may use the Watcher, e.g. call Current,
+// Reload or Subscribe.
+func (w *Watcher[T]) Subscribe(f func(ConfigChange[T])) (unsubscribe func()) {
+	return w.state.Subscribe(func(o Option[ConfigChange[T]]) {
+		f(o.Unwrap())
+	})
+}
+
+// Reload loads the source again and returns the fields which changed. If any
+// did, the new configuration becomes current and subscribers are notified. On
+// error the current configuration is kept.
+func (w *Watcher[T]) Reload() ([]FieldChange, error) {
+	changes, err := w.reloadLocked()
+	if len(changes) > 0 {
+		// Subscribers are notified without holding the reload lock, in the
+		// order the changes were queued.
+		w.state.deliver()
+	}
+
+	return changes, err
+}
+
+// reloadLocked loads the source and queues the changes for subscribers while
+// holding the reload lock.
+func (w *Watcher[T]) reloadLocked() ([]FieldChange, error) {
+	w.reload.Lock()
+	defer w.reload.Unlock()
+
+	cfg, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := changedFields(w.Current(), cfg)
+	if len(changes) > 0 {
+		w.state.enqueue(Some(ConfigChange[T]{Config: cfg, Changes: changes}))
+	}
+
+	return changes, nil
+}
+
+// Watch reloads every interval until ctx is done, passing reload errors to
+// onError if it's not nil. It returns ctx.Err().
+func (w *Watcher[T]) Watch(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := w.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher[T]) load() (T, error) {
+	var cfg T
+	m, err := w.source.Load()
+	if err != nil {
+		return cfg, err
+	}
+
+	err = DecodeMap(m, &cfg, w.opts...)
+	return cfg, err
+}
+
+// changedFields compares the top level fields of two structs like Diff does,
+// naming them like EncodeMap names them.
+func changedFields[T any](old, new T) []FieldChange {
+	ov := addressable(reflect.ValueOf(old))
+	nv := addressable(reflect.ValueOf(new))
+
+	var changes []FieldChange
+	for _, f := range mapFieldsOf(ov.Type()) {
+		d := diffFields(fieldByIndex(ov, f.index, false), fieldByIndex(nv, f.index, false))
+		if d.changed() {
+			changes = append(changes, FieldChange{Field: f.name, Old: diffOption(d.oldVal, d.oldPresent), New: diffOption(d.newVal, d.newPresent)})
+		}
+	}
+
+	return changes
+}
+
+// diffOption returns a value of a fieldDiff as an Option.
+func diffOption(val reflect.Value, present bool) Option[any] {
+	if !present {
+		return None[any]()
+	}
+
+	return Some(val.Interface())
+}
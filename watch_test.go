@@ -0,0 +1,140 @@
+package goption
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchedConfig struct {
+	Host     Option[string] `json:"host"`
+	MaxConns Option[int]    `json:"max_conns"`
+	Debug    bool           `json:"debug"`
+}
+
+func TestWatcherFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("Failed writing config: %s", err)
+		}
+	}
+
+	write(`{"host": "db1", "max_conns": 10}`)
+	w, err := NewWatcher[watchedConfig](FileSource(path))
+	if err != nil {
+		t.Fatalf("Failed creating watcher: %s", err)
+	}
+	if cfg := w.Current(); cfg.Host.Unwrap() != "db1" || cfg.MaxConns.Unwrap() != 10 {
+		t.Errorf("Unexpected initial config: %+v", cfg)
+	}
+
+	var notified []ConfigChange[watchedConfig]
+	unsubscribe := w.Subscribe(func(c ConfigChange[watchedConfig]) {
+		notified = append(notified, c)
+	})
+	defer unsubscribe()
+
+	if changes, err := w.Reload(); err != nil || len(changes) != 0 || len(notified) != 0 {
+		t.Errorf("Expected no changes, got %v (%v)", changes, err)
+	}
+
+	write(`{"host": "db2", "debug": true}`)
+	changes, err := w.Reload()
+	if err != nil {
+		t.Fatalf("Failed reloading: %s", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %v", changes)
+	}
+	if c := changes[0]; c.Field != "host" || c.Old.Unwrap() != "db1" || c.New.Unwrap() != "db2" {
+		t.Errorf("Unexpected host change: %+v", c)
+	}
+	if c := changes[1]; c.Field != "max_conns" || c.Old.Unwrap() != 10 || c.New.Ok() {
+		t.Errorf("Expected max_conns to become None, got %+v", c)
+	}
+	if c := changes[2]; c.Field != "debug" || c.Old.Unwrap() != false || c.New.Unwrap() != true {
+		t.Errorf("Unexpected debug change: %+v", c)
+	}
+	if len(notified) != 1 || notified[0].Config.Host.Unwrap() != "db2" || len(notified[0].Changes) != 3 {
+		t.Errorf("Expected one notification, got %+v", notified)
+	}
+
+	write(`not json`)
+	if _, err := w.Reload(); err == nil {
+		t.Errorf("Expected error for invalid config")
+	}
+	if w.Current().Host.Unwrap() != "db2" {
+		t.Errorf("Expected config to be kept on error, got %+v", w.Current())
+	}
+}
+
+func TestWatcherEnv(t *testing.T) {
+	t.Setenv("GOPTIONTEST_HOST", "envhost")
+	w, err := NewWatcher[watchedConfig](EnvSource("GOPTIONTEST_"))
+	if err != nil {
+		t.Fatalf("Failed creating watcher: %s", err)
+	}
+	if cfg := w.Current(); cfg.Host.Unwrap() != "envhost" || cfg.MaxConns.Ok() {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	t.Setenv("GOPTIONTEST_MAX_CONNS", "5")
+	ctx, cancel := context.WithCancel(context.Background())
+	var current watchedConfig
+	w.Subscribe(func(c ConfigChange[watchedConfig]) {
+		current = w.Current()
+		cancel()
+	})
+	if err := w.Watch(ctx, time.Millisecond, nil); err != context.Canceled {
+		t.Errorf("Expected watch to stop after change, got %v", err)
+	}
+	if n := w.Current().MaxConns; n.UnwrapOr(0) != 5 {
+		t.Errorf("Expected max_conns from env, got %v", n)
+	}
+	if current.MaxConns.UnwrapOr(0) != 5 {
+		t.Errorf("Expected subscriber to read the new config, got %+v", current)
+	}
+}
+
+func TestWatcherReentrantSubscriber(t *testing.T) {
+	host := "a"
+	w, err := NewWatcher[watchedConfig](ConfigSourceFunc(func() (map[string]any, error) {
+		return map[string]any{"host": host}, nil
+	}))
+	if err != nil {
+		t.Fatalf("Failed creating watcher: %s", err)
+	}
+
+	var seen []string
+	w.Subscribe(func(c ConfigChange[watchedConfig]) {
+		seen = append(seen, c.Config.Host.Unwrap())
+		if host == "b" {
+			host = "c"
+			if _, err := w.Reload(); err != nil {
+				t.Errorf("Failed reloading from subscriber: %s", err)
+			}
+			w.Subscribe(func(ConfigChange[watchedConfig]) {})
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		host = "b"
+		if _, err := w.Reload(); err != nil {
+			t.Errorf("Failed reloading: %s", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Reload from a subscriber deadlocked")
+	}
+
+	if len(seen) != 2 || seen[0] != "b" || seen[1] != "c" || w.Current().Host.Unwrap() != "c" {
+		t.Errorf("Expected reloads to be delivered in order, got %v and %+v", seen, w.Current())
+	}
+}
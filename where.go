@@ -0,0 +1,56 @@
+package goption
+
+import (
+	"strings"
+)
+
+// WhereEq returns a condition matching rows whose col equals o, using "?"
+// placeholders: "col = ?" if o is present and "col IS NULL" otherwise.
+func WhereEq[T any](col string, o Option[T]) (string, []any) {
+	if !o.ok {
+		return col + " IS NULL", nil
+	}
+
+	return col + " = ?", []any{o.t}
+}
+
+// WhereNe returns the negation of WhereEq, treating NULL as a distinct value
+// like IS DISTINCT FROM: "(col <> ? OR col IS NULL)" if o is present and
+// "col IS NOT NULL" otherwise.
+func WhereNe[T any](col string, o Option[T]) (string, []any) {
+	if !o.ok {
+		return col + " IS NOT NULL", nil
+	}
+
+	return "(" + col + " <> ? OR " + col + " IS NULL)", []any{o.t}
+}
+
+// WhereIn returns a condition matching rows whose col equals any of opts.
+// Empty options match NULL. If opts is empty the condition matches nothing.
+func WhereIn[T any](col string, opts ...Option[T]) (string, []any) {
+	var args []any
+	matchNull := false
+	for _, o := range opts {
+		if o.ok {
+			args = append(args, o.t)
+		} else {
+			matchNull = true
+		}
+	}
+
+	var in string
+	if len(args) > 0 {
+		in = col + " IN (?" + strings.Repeat(", ?", len(args)-1) + ")"
+	}
+
+	switch {
+	case in != "" && matchNull:
+		return "(" + in + " OR " + col + " IS NULL)", args
+	case in != "":
+		return in, args
+	case matchNull:
+		return col + " IS NULL", nil
+	}
+
+	return "1 = 0", nil
+}
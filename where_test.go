@@ -0,0 +1,46 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+func checkWhere(t *testing.T, cond string, args []any, expected string, expectedArgs ...any) {
+	t.Helper()
+	if cond != expected {
+		t.Errorf("Expected %q, got %q", expected, cond)
+	}
+	if len(args) != len(expectedArgs) || (len(args) > 0 && !reflect.DeepEqual(args, expectedArgs)) {
+		t.Errorf("Expected args %v for %q, got %v", expectedArgs, expected, args)
+	}
+}
+
+func TestWhereEq(t *testing.T) {
+	cond, args := WhereEq("id", Some(1))
+	checkWhere(t, cond, args, "id = ?", 1)
+
+	cond, args = WhereEq("id", None[int]())
+	checkWhere(t, cond, args, "id IS NULL")
+}
+
+func TestWhereNe(t *testing.T) {
+	cond, args := WhereNe("id", Some(1))
+	checkWhere(t, cond, args, "(id <> ? OR id IS NULL)", 1)
+
+	cond, args = WhereNe("id", None[int]())
+	checkWhere(t, cond, args, "id IS NOT NULL")
+}
+
+func TestWhereIn(t *testing.T) {
+	cond, args := WhereIn("id", Some(1), Some(2))
+	checkWhere(t, cond, args, "id IN (?, ?)", 1, 2)
+
+	cond, args = WhereIn("id", Some(1), None[int]())
+	checkWhere(t, cond, args, "(id IN (?) OR id IS NULL)", 1)
+
+	cond, args = WhereIn("id", None[int](), None[int]())
+	checkWhere(t, cond, args, "id IS NULL")
+
+	cond, args = WhereIn[int]("id")
+	checkWhere(t, cond, args, "1 = 0")
+}
@@ -0,0 +1,78 @@
+package goption
+
+import (
+	"context"
+	"sync"
+)
+
+// WorkerPool bounds how many calls MapOption runs concurrently. A pool may be
+// shared by multiple MapOption calls, which then share its workers.
+type WorkerPool struct {
+	sem chan struct{}
+}
+
+// NewWorkerPool returns a WorkerPool running at most workers calls at once.
+// It panics if workers is less than one.
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers < 1 {
+		panic("goption: WorkerPool needs at least one worker")
+	}
+
+	return &WorkerPool{sem: make(chan struct{}, workers)}
+}
+
+// PoolMapOption calls f for every item using the workers of p and returns the
+// results in the order of items. Items which weren't started before ctx was
+// done are left empty and ctx.Err() is returned once the started calls have
+// finished. A panic in f is propagated to the caller of PoolMapOption.
+func PoolMapOption[I, O any](ctx context.Context, p *WorkerPool, items []I, f func(context.Context, I) Option[O]) ([]Option[O], error) {
+	results := make([]Option[O], len(items))
+
+	var (
+		wg       sync.WaitGroup
+		panicMu  sync.Mutex
+		panicked any
+		didPanic bool
+	)
+
+	var err error
+	for i, item := range items {
+		acquired := false
+		select {
+		case <-ctx.Done():
+		case p.sem <- struct{}{}:
+			acquired = true
+		}
+		// Don't start items once ctx is done, even if a worker was free too.
+		if err = ctx.Err(); err != nil {
+			if acquired {
+				<-p.sem
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, item I) {
+			defer wg.Done()
+			defer func() { <-p.sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					panicMu.Lock()
+					if !didPanic {
+						panicked, didPanic = r, true
+					}
+					panicMu.Unlock()
+				}
+			}()
+
+			results[i] = f(ctx, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+	if didPanic {
+		panic(panicked)
+	}
+
+	return results, err
+}
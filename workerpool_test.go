@@ -0,0 +1,73 @@
+package goption
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolMapOption(t *testing.T) {
+	pool := NewWorkerPool(3)
+
+	var running, maxRunning atomic.Int32
+	results, err := PoolMapOption(context.Background(), pool, []int{1, 2, 3, 4, 5, 6, 7, 8}, func(ctx context.Context, i int) Option[int] {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		if i%2 == 0 {
+			return None[int]()
+		}
+		return Some(i * 10)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for i, r := range results {
+		item := i + 1
+		if item%2 == 0 && r.Ok() || item%2 == 1 && r.UnwrapOr(0) != item*10 {
+			t.Errorf("Unexpected result for %d: %v", item, r)
+		}
+	}
+	if m := maxRunning.Load(); m > 3 {
+		t.Errorf("Expected at most 3 concurrent calls, got %d", m)
+	}
+}
+
+func TestPoolMapOptionCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := PoolMapOption(ctx, NewWorkerPool(1), []int{1, 2, 3}, func(ctx context.Context, i int) Option[int] {
+		cancel()
+		return Some(i)
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(results) != 3 || !results[0].Ok() || results[2].Ok() {
+		t.Errorf("Expected only the first item to be mapped, got %v", results)
+	}
+}
+
+func TestPoolMapOptionPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected propagated panic, got %v", r)
+		}
+	}()
+
+	_, _ = PoolMapOption(context.Background(), NewWorkerPool(2), []int{1, 2}, func(ctx context.Context, i int) Option[int] {
+		if i == 2 {
+			panic("boom")
+		}
+		return Some(i)
+	})
+	t.Errorf("Expected PoolMapOption to panic")
+}
@@ -0,0 +1,26 @@
+package goption
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v3
+// and yaml.v2. Empty options are encoded as null.
+func (o Option[T]) MarshalYAML() (any, error) {
+	if !o.ok {
+		return nil, nil
+	}
+
+	return o.t, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of yaml.v2, which
+// yaml.v3 supports too, so this package doesn't depend on either. Fields
+// whose key is missing are left unchanged, and yaml.v3 leaves fields set to
+// null unchanged without calling UnmarshalYAML; decode with
+// goptionyaml.Unmarshal for null to empty them.
+func (o *Option[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var t *T
+	if err := unmarshal(&t); err != nil {
+		return err
+	}
+
+	*o = FromRef(t)
+	return nil
+}
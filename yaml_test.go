@@ -0,0 +1,38 @@
+package goption
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(struct {
+		A Option[int]
+		B Option[int]
+		C Option[Bar]
+	}{A: Some(3), C: Some(Bar{Baz: "x"})})
+	if expected := "a: 3\nb: null\nc:\n    baz: x\n"; err != nil || string(data) != expected {
+		t.Errorf("Expected %q, got %q (%v)", expected, data, err)
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	var o Option[Bar]
+	if err := yaml.Unmarshal([]byte("baz: x"), &o); err != nil || o.Unwrap().Baz != "x" {
+		t.Errorf("Expected decoded struct, got %v (%v)", o, err)
+	}
+
+	var s struct {
+		A Option[int]
+		B Option[string]
+	}
+	if err := yaml.Unmarshal([]byte("a: 1\nb: null\n"), &s); err != nil || s.A.Unwrap() != 1 || s.B.Ok() {
+		t.Errorf("Expected decoded and empty fields, got %+v (%v)", s, err)
+	}
+
+	i := Some(1)
+	if err := yaml.Unmarshal([]byte("x"), &i); err == nil || i.Unwrap() != 1 {
+		t.Errorf("Expected failed decoding to keep the value, got %v (%v)", i, err)
+	}
+}
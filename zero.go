@@ -0,0 +1,52 @@
+package goption
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+var someZeroIsZero atomic.Bool
+
+// SetSomeZeroIsZero changes what IsZero considers zero. By default only
+// empty options are zero, so fields tagged omitzero keep explicit zero
+// values. When enabled, present options holding the zero value of T are
+// zero as well.
+func SetSomeZeroIsZero(enabled bool) {
+	someZeroIsZero.Store(enabled)
+}
+
+// IsZero reports whether o is empty. It's used by encoding/json, since Go
+// 1.24, and the other codecs to decide which fields tagged omitzero are
+// omitted. See SetSomeZeroIsZero for treating Some(zero) as zero too.
+func (o Option[T]) IsZero() bool {
+	if !o.ok {
+		return true
+	}
+	if !someZeroIsZero.Load() {
+		return false
+	}
+
+	return isZeroValue(reflect.ValueOf(&o.t).Elem())
+}
+
+type zeroer interface {
+	IsZero() bool
+}
+
+// isZeroValue reports whether rv is zero like encoding/json's omitzero does,
+// preferring an IsZero method over comparing with the zero value.
+func isZeroValue(rv reflect.Value) bool {
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return true
+	}
+	if z, ok := rv.Interface().(zeroer); ok {
+		return z.IsZero()
+	}
+	if rv.CanAddr() {
+		if z, ok := rv.Addr().Interface().(zeroer); ok {
+			return z.IsZero()
+		}
+	}
+
+	return rv.IsZero()
+}
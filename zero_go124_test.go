@@ -0,0 +1,31 @@
+//go:build go1.24
+
+package goption
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// encoding/json honors omitzero since Go 1.24.
+func TestOmitZeroJSON(t *testing.T) {
+	v := omitZeroStruct{Count: Some(0)}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed marshaling: %s", err)
+	}
+	if string(data) != `{"count":0}` {
+		t.Errorf("Expected only explicit zero count, got %s", data)
+	}
+
+	SetSomeZeroIsZero(true)
+	defer SetSomeZeroIsZero(false)
+
+	data, err = json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed marshaling: %s", err)
+	}
+	if string(data) != `{}` {
+		t.Errorf("Expected all fields omitted, got %s", data)
+	}
+}
@@ -0,0 +1,53 @@
+package goption
+
+import (
+	"testing"
+	"time"
+)
+
+type omitZeroStruct struct {
+	Count Option[int]       `json:"count,omitzero"`
+	Name  Option[string]    `json:"name,omitzero"`
+	At    Option[time.Time] `json:"at,omitzero"`
+	Plain int               `json:"plain,omitzero"`
+}
+
+func TestIsZero(t *testing.T) {
+	if !None[int]().IsZero() {
+		t.Errorf("Expected None to be zero")
+	}
+	if Some(0).IsZero() {
+		t.Errorf("Expected Some(0) not to be zero")
+	}
+
+	SetSomeZeroIsZero(true)
+	defer SetSomeZeroIsZero(false)
+
+	if !Some(0).IsZero() || !Some(time.Time{}).IsZero() || !Some[*int](nil).IsZero() {
+		t.Errorf("Expected Some(zero) to be zero when enabled")
+	}
+	if Some(1).IsZero() || Some(time.Now()).IsZero() {
+		t.Errorf("Expected Some(non-zero) not to be zero when enabled")
+	}
+}
+
+func TestOmitZeroMap(t *testing.T) {
+	m, err := EncodeMap(omitZeroStruct{Count: Some(0), Name: Some("x")})
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	if len(m) != 2 || m["count"] != 0 || m["name"] != "x" {
+		t.Errorf("Expected count and name only, got %v", m)
+	}
+
+	SetSomeZeroIsZero(true)
+	defer SetSomeZeroIsZero(false)
+
+	m, err = EncodeMap(omitZeroStruct{Count: Some(0), Name: Some("x")})
+	if err != nil {
+		t.Fatalf("Failed encoding: %s", err)
+	}
+	if len(m) != 1 || m["name"] != "x" {
+		t.Errorf("Expected name only, got %v", m)
+	}
+}
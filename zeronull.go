@@ -0,0 +1,42 @@
+package goption
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// ZeroAsNull is an Option which is written to databases as NULL when it holds
+// the zero value of T, for legacy columns where an empty string or zero means
+// "not set". Use it as the field type of such columns or wrap arguments with
+// WriteZeroAsNull. Value and ValueContext apply the policy; scanning and the
+// JSON, text, YAML and binary encodings are those of the Option.
+type ZeroAsNull[T comparable] struct {
+	Option[T]
+}
+
+// WriteZeroAsNull returns o wrapped so Some(zero) is written as NULL.
+func WriteZeroAsNull[T comparable](o Option[T]) ZeroAsNull[T] {
+	return ZeroAsNull[T]{Option: o}
+}
+
+// Value implements driver.Valuer
+func (z ZeroAsNull[T]) Value() (driver.Value, error) {
+	var zero T
+	if !z.ok || z.t == zero {
+		return nil, nil
+	}
+
+	return z.Option.Value()
+}
+
+// ValueContext implements ValuerContext like Option.ValueContext, writing
+// Some(zero) as NULL like Value does. Without it the promoted method of the
+// Option would skip the policy.
+func (z ZeroAsNull[T]) ValueContext(ctx context.Context) (driver.Value, error) {
+	var zero T
+	if !z.ok || z.t == zero {
+		return nil, nil
+	}
+
+	return z.Option.ValueContext(ctx)
+}
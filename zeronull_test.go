@@ -0,0 +1,49 @@
+package goption
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+func TestZeroAsNull(t *testing.T) {
+	cases := []struct {
+		valuer   driver.Valuer
+		expected driver.Value
+	}{
+		{WriteZeroAsNull(Some("")), nil},
+		{WriteZeroAsNull(Some(0)), nil},
+		{WriteZeroAsNull(None[string]()), nil},
+		{WriteZeroAsNull(Some("x")), "x"},
+		{WriteZeroAsNull(Some(3)), int64(3)},
+	}
+	for _, c := range cases {
+		if v, err := c.valuer.Value(); err != nil || v != c.expected {
+			t.Errorf("Expected %v for %v, got %v (%v)", c.expected, c.valuer, v, err)
+		}
+	}
+
+	var z ZeroAsNull[string]
+	if err := z.Scan(""); err != nil || z.Unwrap() != "" {
+		t.Errorf("Expected empty string to scan as is, got %v (%v)", z, err)
+	}
+
+	data, err := json.Marshal(WriteZeroAsNull(Some(0)))
+	if err != nil || string(data) != "0" {
+		t.Errorf("Expected JSON of the Option, got %s (%v)", data, err)
+	}
+}
+
+func TestZeroAsNullValueContext(t *testing.T) {
+	ctx := context.Background()
+	if v, err := WriteZeroAsNull(Some("")).ValueContext(ctx); err != nil || v != nil {
+		t.Errorf("Expected NULL for the zero value, got %#v (%v)", v, err)
+	}
+	if v, err := WriteZeroAsNull(Some("x")).ValueContext(ctx); err != nil || v != "x" {
+		t.Errorf("Expected x, got %#v (%v)", v, err)
+	}
+	if v, err := WithValueContext(ctx, WriteZeroAsNull(Some(0))).Value(); err != nil || v != nil {
+		t.Errorf("Expected NULL through WithValueContext, got %#v (%v)", v, err)
+	}
+}
@@ -0,0 +1,66 @@
+package goption
+
+// Pair holds two values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple holds three values.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Quad holds four values.
+type Quad[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Zip returns the values of a and b as a Pair if both are present.
+func Zip[A, B any](a Option[A], b Option[B]) Option[Pair[A, B]] {
+	return ZipWith(a, b, func(a A, b B) Pair[A, B] {
+		return Pair[A, B]{First: a, Second: b}
+	})
+}
+
+// Zip3 returns the values of a, b and c as a Triple if all are present.
+func Zip3[A, B, C any](a Option[A], b Option[B], c Option[C]) Option[Triple[A, B, C]] {
+	if !a.ok || !b.ok || !c.ok {
+		return None[Triple[A, B, C]]()
+	}
+
+	return Some(Triple[A, B, C]{First: a.t, Second: b.t, Third: c.t})
+}
+
+// Zip4 returns the values of a, b, c and d as a Quad if all are present.
+func Zip4[A, B, C, D any](a Option[A], b Option[B], c Option[C], d Option[D]) Option[Quad[A, B, C, D]] {
+	if !a.ok || !b.ok || !c.ok || !d.ok {
+		return None[Quad[A, B, C, D]]()
+	}
+
+	return Some(Quad[A, B, C, D]{First: a.t, Second: b.t, Third: c.t, Fourth: d.t})
+}
+
+// ZipWith applies f to the values of a and b if both are present.
+func ZipWith[A, B, C any](a Option[A], b Option[B], f func(A, B) C) Option[C] {
+	if !a.ok || !b.ok {
+		return None[C]()
+	}
+
+	return Some(f(a.t, b.t))
+}
+
+// Unzip splits a Pair option into options of its values, which are both
+// empty if o is.
+func Unzip[A, B any](o Option[Pair[A, B]]) (Option[A], Option[B]) {
+	if !o.ok {
+		return None[A](), None[B]()
+	}
+
+	return Some(o.t.First), Some(o.t.Second)
+}
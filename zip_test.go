@@ -0,0 +1,54 @@
+package goption
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestZip(t *testing.T) {
+	if p := Zip(Some(1), Some("a")); p.Unwrap() != (Pair[int, string]{First: 1, Second: "a"}) {
+		t.Errorf("Expected pair, got %v", p)
+	}
+	if p := Zip(None[int](), Some("a")); p.Ok() {
+		t.Errorf("Expected empty option, got %v", p)
+	}
+	if p := Zip(Some(1), None[string]()); p.Ok() {
+		t.Errorf("Expected empty option, got %v", p)
+	}
+
+	if tr := Zip3(Some(1), Some("a"), Some(true)); tr.Unwrap() != (Triple[int, string, bool]{1, "a", true}) {
+		t.Errorf("Expected triple, got %v", tr)
+	}
+	if tr := Zip3(Some(1), Some("a"), None[bool]()); tr.Ok() {
+		t.Errorf("Expected empty option, got %v", tr)
+	}
+
+	if q := Zip4(Some(1), Some("a"), Some(true), Some(1.5)); q.Unwrap() != (Quad[int, string, bool, float64]{1, "a", true, 1.5}) {
+		t.Errorf("Expected quad, got %v", q)
+	}
+	if q := Zip4(None[int](), Some("a"), Some(true), Some(1.5)); q.Ok() {
+		t.Errorf("Expected empty option, got %v", q)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	join := func(i int, s string) string { return strconv.Itoa(i) + s }
+	if v := ZipWith(Some(1), Some("a"), join); v.Unwrap() != "1a" {
+		t.Errorf("Expected 1a, got %v", v)
+	}
+	if v := ZipWith(Some(1), None[string](), join); v.Ok() {
+		t.Errorf("Expected empty option, got %v", v)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := Unzip(Zip(Some(1), Some("a")))
+	if a.Unwrap() != 1 || b.Unwrap() != "a" {
+		t.Errorf("Expected 1 and a, got %v and %v", a, b)
+	}
+
+	a, b = Unzip(None[Pair[int, string]]())
+	if a.Ok() || b.Ok() {
+		t.Errorf("Expected empty options, got %v and %v", a, b)
+	}
+}